@@ -2,10 +2,10 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/fschuetz04/simgo"
+	"github.com/fschuetz04/simgo/dist"
 )
 
 const (
@@ -24,34 +24,36 @@ func customer(proc simgo.Process, id int, counters *simgo.Resource) {
 	proc.Wait(proc.AnyOf(request, timeout))
 
 	if !request.Triggered() {
-		request.Abort()
+		request.Cancel()
 		fmt.Printf("[%5.1f] Customer %d leaves unhappy\n", proc.Now(), id)
 		return
 	}
 
+	// customer does not renege anymore
+	timeout.Unschedule()
+
 	fmt.Printf("[%5.1f] Customer %d is served\n", proc.Now(), id)
 
-	delay := rand.ExpFloat64() * MeanTimeInBank
+	delay := dist.NewExponential(proc.Stream("service"), MeanTimeInBank).Sample()
 	proc.Wait(proc.Timeout(delay))
 
 	fmt.Printf("[%5.1f] Customer %d leaves\n", proc.Now(), id)
-	counters.Release()
+	request.Release()
 }
 
 func customerSource(proc simgo.Process) {
 	counters := simgo.NewResource(proc.Simulation, NCounters)
+	arrivals := dist.NewExponential(proc.Stream("arrivals"), MeanArrivalInterval)
 
 	for id := 1; id <= NCustomers; id++ {
-		proc.ProcessReflect(customer, id, counters)
-		delay := rand.ExpFloat64() * MeanArrivalInterval
+		simgo.Process2(proc.Simulation, customer, id, counters)
+		delay := arrivals.Sample()
 		proc.Wait(proc.Timeout(delay))
 	}
 }
 
 func main() {
-	rand.Seed(time.Now().Unix())
-
-	sim := simgo.NewSimulation()
+	sim := simgo.NewSimulation(simgo.WithSeed(uint64(time.Now().UnixNano())))
 
 	sim.Process(customerSource)
 
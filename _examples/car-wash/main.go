@@ -2,10 +2,10 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/fschuetz04/simgo"
+	"github.com/fschuetz04/simgo/dist"
 )
 
 const (
@@ -23,33 +23,34 @@ func wash(proc simgo.Process, id int) {
 func car(proc simgo.Process, id int, machines *simgo.Resource) {
 	fmt.Printf("[%5.1f] Car %d arrives\n", proc.Now(), id)
 
-	proc.Wait(machines.Request())
+	request := machines.Request()
+	proc.Wait(request)
 
 	fmt.Printf("[%5.1f] Car %d enters\n", proc.Now(), id)
 
-	proc.Wait(proc.ProcessReflect(wash, id))
+	proc.Wait(simgo.Process1(proc.Simulation, wash, id))
 
 	fmt.Printf("[%5.1f] Car %d leaves\n", proc.Now(), id)
-	machines.Release()
+	request.Release()
 }
 
 func carSource(proc simgo.Process, machines *simgo.Resource) {
+	arrivals := dist.NewExponential(proc.Stream("arrivals"), MeanArrivalTime)
+
 	for id := 1; ; id++ {
 		if id > NInitialCars {
-			proc.Wait(proc.Timeout(rand.ExpFloat64() * MeanArrivalTime))
+			proc.Wait(proc.Timeout(arrivals.Sample()))
 		}
 
-		proc.ProcessReflect(car, id, machines)
+		simgo.Process2(proc.Simulation, car, id, machines)
 	}
 }
 
 func main() {
-	rand.Seed(time.Now().Unix())
-
-	sim := simgo.NewSimulation()
+	sim := simgo.NewSimulation(simgo.WithSeed(uint64(time.Now().UnixNano())))
 	machines := simgo.NewResource(sim, NMachines)
 
-	sim.ProcessReflect(carSource, machines)
+	simgo.Process1(sim, carSource, machines)
 
 	sim.RunUntil(20)
 }
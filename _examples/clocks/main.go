@@ -16,8 +16,8 @@ func clock(proc simgo.Process, name string, delay float64) {
 func main() {
 	sim := simgo.NewSimulation()
 
-	sim.ProcessReflect(clock, "slow", 2)
-	sim.ProcessReflect(clock, "fast", 1)
+	simgo.Process2(sim, clock, "slow", 2)
+	simgo.Process2(sim, clock, "fast", 1)
 
 	sim.RunUntil(5)
 }
@@ -22,19 +22,20 @@ const (
 	TankTruckTime      = 300
 )
 
-func randUniformInt(min int, max int) int {
-	return rand.Intn(max-min+1) + min
+func randUniformInt(r *rand.Rand, min int, max int) int {
+	return r.Intn(max-min+1) + min
 }
 
-func car(proc simgo.Process, i int, pumps *simgo.Resource, stationFuel *Container) {
+func car(proc simgo.Process, i int, pumps *simgo.Resource, stationFuel *simgo.Container) {
 	fmt.Printf("[%6.1f] Car %d arrives\n", proc.Now(), i)
 	start := proc.Now()
 
-	proc.Wait(pumps.Request())
+	request := pumps.Request()
+	proc.Wait(request)
 
-	fmt.Printf("[%6.1f] Car %d gets to a pump, station fuel lvl = %f\n", proc.Now(), i, stationFuel.lvl)
+	fmt.Printf("[%6.1f] Car %d gets to a pump, station fuel lvl = %f\n", proc.Now(), i, stationFuel.Level())
 
-	lvl := randUniformInt(MinCarFuelLvl, MaxCarFuelLvl)
+	lvl := randUniformInt(proc.Rand("fuel"), MinCarFuelLvl, MaxCarFuelLvl)
 	amount := float64(CarFuelCap - lvl)
 	proc.Wait(stationFuel.Get(amount))
 
@@ -45,45 +46,44 @@ func car(proc simgo.Process, i int, pumps *simgo.Resource, stationFuel *Containe
 
 	fmt.Printf("[%6.1f] Car %d finished refueling and leaves after %.1f\n", proc.Now(), i, proc.Now()-start)
 
-	pumps.Release()
+	request.Release()
 }
 
-func carSource(proc simgo.Process, stationFuel *Container) {
+func carSource(proc simgo.Process, stationFuel *simgo.Container) {
 	pumps := simgo.NewResource(proc.Simulation, NPumps)
+	arrivals := proc.Rand("arrivals")
 
 	for i := 1; ; i++ {
-		delay := randUniformInt(MinArrivalInterval, MaxArrivalInterval)
+		delay := randUniformInt(arrivals, MinArrivalInterval, MaxArrivalInterval)
 		proc.Wait(proc.Timeout(float64(delay)))
 
-		proc.ProcessReflect(car, i, pumps, stationFuel)
+		simgo.Process3(proc.Simulation, car, i, pumps, stationFuel)
 	}
 }
 
-func tankTruck(proc simgo.Process, stationFuel *Container) {
+func tankTruck(proc simgo.Process, stationFuel *simgo.Container) {
 	for {
 		proc.Wait(proc.Timeout(TankTruckTime))
 
-		if stationFuel.lvl <= stationFuel.cap*Threshold {
+		if stationFuel.Level() <= stationFuel.Capacity()*Threshold {
 			fmt.Printf("[%6.1f] Station fuel level below threshold, tank truck is called\n", proc.Now())
 
 			proc.Wait(proc.Timeout(TankTruckTime))
 
 			fmt.Printf("[%6.1f] Tank truck arrives and refills station\n", proc.Now())
 
-			stationFuel.Put(stationFuel.cap - stationFuel.lvl)
+			stationFuel.Put(stationFuel.Capacity() - stationFuel.Level())
 		}
 	}
 }
 
 func main() {
-	rand.Seed(time.Now().Unix())
+	sim := simgo.NewSimulation(simgo.WithSeed(uint64(time.Now().UnixNano())))
 
-	sim := simgo.NewSimulation()
+	stationFuel := simgo.NewContainerWithLevel(sim, StationFuelCap, StationFuelCap)
 
-	stationFuel := NewFilledCappedContainer(sim, StationFuelCap, StationFuelCap)
-
-	sim.ProcessReflect(carSource, stationFuel)
-	sim.ProcessReflect(tankTruck, stationFuel)
+	simgo.Process1(sim, carSource, stationFuel)
+	simgo.Process1(sim, tankTruck, stationFuel)
 
 	sim.RunUntil(Target)
 }
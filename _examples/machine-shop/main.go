@@ -2,9 +2,10 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
+	"math"
 
 	"github.com/fschuetz04/simgo"
+	"github.com/fschuetz04/simgo/dist"
 )
 
 const (
@@ -13,7 +14,7 @@ const (
 	NRepairMen = 1
 	NWeeks     = 4
 
-	// normal distribution
+	// normal distribution truncated at zero
 	TimeForPartMean   = 10
 	TimeForPartStdDev = 2
 
@@ -21,42 +22,49 @@ const (
 	TimeToFailureMean = 300
 )
 
-func machineProduction(proc simgo.Process, nPartsMade *int, repairMen *simgo.Resource, failure **simgo.Event) {
+func machineProduction(proc simgo.Process, id int, nPartsMade *int, repairMen *simgo.Resource, broken *bool) {
+	production := dist.NewTruncatedNormal(proc.Stream(fmt.Sprintf("production %d", id)), TimeForPartMean, TimeForPartStdDev, 0, math.Inf(1))
+
 	for {
-		timeForPart := rand.NormFloat64()*TimeForPartStdDev + TimeForPartMean
+		timeForPart := production.Sample()
 
 		for {
 			start := proc.Now()
-			timeout := proc.Timeout(timeForPart)
-			proc.Wait(proc.AnyOf(timeout, *failure))
+			err := proc.Wait(proc.Timeout(timeForPart))
 
-			if timeout.Triggered() {
+			if err == nil {
 				// part is finished
 				*nPartsMade++
 				break
 			}
 
-			// machine failred, calculate remaining time for part and wait for repair
+			// machine failed, calculate remaining time for part and wait for repair
+			*broken = true
 			timeForPart -= proc.Now() - start
-			proc.Wait(repairMen.Request())
+			request := repairMen.Request()
+			proc.Wait(request)
 			proc.Wait(proc.Timeout(RepairTime))
-			repairMen.Release()
+			request.Release()
+			*broken = false
 		}
 	}
 }
 
-func machineFailure(proc simgo.Process, failure **simgo.Event) {
+func machineFailure(proc simgo.Process, id int, production simgo.Process, broken *bool) {
+	failures := dist.NewExponential(proc.Stream(fmt.Sprintf("failure %d", id)), TimeToFailureMean)
+
 	for {
-		proc.Wait(proc.Timeout(rand.ExpFloat64() * TimeToFailureMean))
-		(*failure).Trigger()
-		*failure = proc.Event()
+		proc.Wait(proc.Timeout(failures.Sample()))
+		if !*broken {
+			production.Interrupt(nil)
+		}
 	}
 }
 
-func machine(proc simgo.Process, nPartsMade *int, repairMen *simgo.Resource) {
-	failure := proc.Event()
-	proc.ProcessReflect(machineProduction, nPartsMade, repairMen, &failure)
-	proc.ProcessReflect(machineFailure, &failure)
+func machine(proc simgo.Process, id int, nPartsMade *int, repairMen *simgo.Resource) {
+	broken := false
+	production := simgo.Process4(proc.Simulation, machineProduction, id, nPartsMade, repairMen, &broken)
+	simgo.Process3(proc.Simulation, machineFailure, id, production, &broken)
 }
 
 func main() {
@@ -66,7 +74,7 @@ func main() {
 	nPartsMade := make([]int, NMachines)
 
 	for i := 0; i < NMachines; i++ {
-		sim.ProcessReflect(machine, &nPartsMade[i], repairMen)
+		simgo.Process3(sim, machine, i, &nPartsMade[i], repairMen)
 	}
 
 	sim.RunUntil(NWeeks * 7 * 24 * 60)
@@ -28,8 +28,8 @@ func main() {
 	pongEv := &PingPongEvent{Event: sim.Event(), otherEv: nil}
 	pingEv := &PingPongEvent{Event: sim.Timeout(0), otherEv: pongEv}
 
-	sim.ProcessReflect(party, "ping", pingEv, 1)
-	sim.ProcessReflect(party, "pong", pongEv, 2)
+	simgo.Process3(sim, party, "ping", pingEv, 1)
+	simgo.Process3(sim, party, "pong", pongEv, 2)
 
 	sim.RunUntil(8)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"time"
@@ -9,6 +10,7 @@ import (
 )
 
 func badProcess(proc simgo.Process) {
+	// the first event is never triggered, so the process is blocked forever
 	ev := proc.AllOf(proc.Event(), proc.Timeout(10_000))
 	proc.Wait(ev)
 }
@@ -33,6 +35,11 @@ func main() {
 	sim.Run()
 	fmt.Printf("[    End]         => %7d\n", runtime.NumGoroutine())
 
+	var deadlock *simgo.DeadlockError
+	if errors.As(sim.CheckDeadlock(), &deadlock) {
+		fmt.Printf("[Blocked]         => %7d\n", len(deadlock.Blocked))
+	}
+
 	sim.Shutdown()
 	time.Sleep(time.Millisecond)
 	fmt.Printf("[    Off]         => %7d\n", runtime.NumGoroutine())
@@ -2,6 +2,10 @@ package simgo
 
 // AllOf creates and returns a pending event which is triggered when all of the
 // given events are processed.
+//
+// The value of the returned event is a slice holding the values of the given
+// events in the given order. If any of the given events fails, the returned
+// event fails with the same error.
 func (sim *Simulation) AllOf(evs ...Awaitable) *Event {
 	n := len(evs)
 
@@ -14,28 +18,51 @@ func (sim *Simulation) AllOf(evs ...Awaitable) *Event {
 		}
 	}
 
-	// check how many events are already processed
+	// check how many events are already processed, and fail immediately if any
+	// of them failed
 	for _, ev := range evs {
 		if ev.Processed() {
+			if err := ev.Err(); err != nil {
+				ev := sim.Event()
+				ev.Fail(err)
+				return ev
+			}
+
 			n--
 		}
 	}
 
+	// values returns the values of all events
+	values := func() []any {
+		values := make([]any, len(evs))
+		for i, ev := range evs {
+			values[i] = ev.Value()
+		}
+		return values
+	}
+
 	// if no events are given or all events are already processed, the returned
 	// event is immediately triggered
 	if n == 0 {
-		return sim.Timeout(0)
+		ev := sim.Event()
+		ev.Succeed(values())
+		return ev
 	}
 
 	allOf := sim.Event()
 
 	for _, ev := range evs {
-		// when the event is processed, check whether the condition is
-		// fulfilled, and trigger the returned event if so
+		// when the event is processed, check whether it failed or the condition
+		// is fulfilled, and trigger the returned event if so
 		ev.AddHandler(func(ev *Event) {
+			if err := ev.Err(); err != nil {
+				allOf.Fail(err)
+				return
+			}
+
 			n--
 			if n == 0 {
-				allOf.Trigger()
+				allOf.Succeed(values())
 			}
 		})
 
@@ -1,6 +1,7 @@
 package simgo_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/fschuetz04/simgo"
@@ -121,3 +122,45 @@ func TestAllOfBecomingAborted(t *testing.T) {
 	assertf(t, finished, "Process did not start waiting")
 	assertf(t, aborted, "allOf was not aborted when one event became aborted")
 }
+
+func TestAllOfValues(t *testing.T) {
+	sim := simgo.NewSimulation()
+	finished := false
+
+	sim.Process(func(proc simgo.Process) {
+		ev1 := proc.Event()
+		ev1.Succeed(1)
+		ev2 := proc.Timeout(5)
+		ev2.Succeed(2)
+		value, err := proc.WaitValue(proc.AllOf(ev1, ev2))
+		assertf(t, err == nil, "err == %v", err)
+		values, ok := value.([]any)
+		assertf(t, ok && len(values) == 2, "value == %v", value)
+		if ok && len(values) == 2 {
+			assertf(t, values[0] == 1 && values[1] == 2, "values == %v", values)
+		}
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestAllOfFailed(t *testing.T) {
+	sim := simgo.NewSimulation()
+	errFailed := errors.New("failed")
+	finished := false
+
+	sim.Process(func(proc simgo.Process) {
+		ev1 := proc.Timeout(5)
+		ev2 := proc.Event()
+		ev2.Fail(errFailed)
+		err := proc.Wait(proc.AllOf(ev1, ev2))
+		assertf(t, err == errFailed, "err == %v", err)
+		assertf(t, proc.Now() == 0, "proc.Now() == %f", proc.Now())
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
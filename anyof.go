@@ -2,6 +2,10 @@ package simgo
 
 // AnyOf creates and returns a pending event which is triggered when any of the
 // given events is processed.
+//
+// The value of the returned event is the value of the first processed event.
+// If the first processed event failed, the returned event fails with the same
+// error.
 func (sim *Simulation) AnyOf(evs ...Awaitable) *Event {
 	// if no events are given, the returned event is immediately triggered
 	if len(evs) == 0 {
@@ -12,7 +16,13 @@ func (sim *Simulation) AnyOf(evs ...Awaitable) *Event {
 	// triggered
 	for _, ev := range evs {
 		if ev.Processed() {
-			return sim.Timeout(0)
+			anyOf := sim.Event()
+			if err := ev.Err(); err != nil {
+				anyOf.Fail(err)
+			} else {
+				anyOf.Succeed(ev.Value())
+			}
+			return anyOf
 		}
 	}
 
@@ -36,7 +46,13 @@ func (sim *Simulation) AnyOf(evs ...Awaitable) *Event {
 	for _, ev := range evs {
 		// when the event is processed, the condition is fulfilled, so trigger
 		// the returned event
-		ev.AddHandler(func(ev *Event) { anyOf.Trigger() })
+		ev.AddHandler(func(ev *Event) {
+			if err := ev.Err(); err != nil {
+				anyOf.Fail(err)
+			} else {
+				anyOf.Succeed(ev.Value())
+			}
+		})
 
 		// if the event gets aborted, check whether this was the last non-aborted
 		// event non-aborted event, and aborted the returned event if so
@@ -1,6 +1,7 @@
 package simgo_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/fschuetz04/simgo"
@@ -145,3 +146,39 @@ func TestAnyOfAllBecomingAborted(t *testing.T) {
 	assertf(t, finished, "process did not start waiting")
 	assertf(t, aborted, "anyOf event was not aborted when all events became aborted")
 }
+
+func TestAnyOfValue(t *testing.T) {
+	sim := simgo.NewSimulation()
+	finished := false
+
+	sim.Process(func(proc simgo.Process) {
+		ev1 := proc.Event()
+		ev2 := proc.Event()
+		ev2.Succeed(2)
+		value, err := proc.WaitValue(proc.AnyOf(ev1, ev2))
+		assertf(t, err == nil, "err == %v", err)
+		assertf(t, value == 2, "value == %v", value)
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestAnyOfFailed(t *testing.T) {
+	sim := simgo.NewSimulation()
+	errFailed := errors.New("failed")
+	finished := false
+
+	sim.Process(func(proc simgo.Process) {
+		ev1 := proc.Timeout(5)
+		ev2 := proc.Event()
+		ev2.Fail(errFailed)
+		err := proc.Wait(proc.AnyOf(ev1, ev2))
+		assertf(t, err == errFailed, "err == %v", err)
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
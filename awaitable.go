@@ -8,6 +8,13 @@ type Awaitable interface {
 	// Aborted must return true when the event is aborted.
 	Aborted() bool
 
+	// Value must return the value of the event once it is processed.
+	Value() any
+
+	// Err must return the error of the event once it is processed, or nil if
+	// the event did not fail.
+	Err() error
+
 	// AddHandler must add the given normal handler. When the event is
 	// processed, the normal handler must be called.
 	AddHandler(handler Handler)
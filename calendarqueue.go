@@ -0,0 +1,237 @@
+package simgo
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// minBuckets is the minimum number of buckets of a calendar queue.
+	minBuckets = 2
+
+	// widthSamples is the maximum number of events sampled to estimate the
+	// bucket width of a calendar queue.
+	widthSamples = 25
+)
+
+// calendarQueue is an event list implemented as a calendar queue as described
+// by R. Brown, "Calendar queues: a fast O(1) priority queue implementation for
+// the simulation event set problem", 1988.
+//
+// Time is divided into days of equal width, which are mapped to a fixed
+// number of buckets like the days of a year. Each bucket holds its events
+// sorted. The next event is found by scanning the buckets day by day starting
+// at the day of the previous one. The number of buckets and the width are
+// adapted when the number of events changes considerably. The index of each
+// event is its bucket.
+type calendarQueue struct {
+	// buckets holds the sorted events of each bucket.
+	buckets [][]*Event
+
+	// width is the width of a day.
+	width float64
+
+	// size is the number of contained events.
+	size int
+
+	// day is the day at which the search for the next event starts. No
+	// contained event is scheduled before this day.
+	day float64
+
+	// bucket is the bucket of the day at which the search for the next event
+	// starts.
+	bucket int
+}
+
+// newCalendarQueue creates an empty calendar queue.
+func newCalendarQueue() *calendarQueue {
+	return &calendarQueue{buckets: make([][]*Event, minBuckets), width: 1}
+}
+
+// len returns the number of scheduled events.
+func (cq *calendarQueue) len() int {
+	return cq.size
+}
+
+// insert adds the given event.
+func (cq *calendarQueue) insert(ev *Event) {
+	cq.add(ev)
+	cq.size++
+
+	if cq.size > 2*len(cq.buckets) {
+		cq.resize(2 * len(cq.buckets))
+	}
+}
+
+// min returns the next event without removing it.
+func (cq *calendarQueue) min() *Event {
+	if cq.size == 0 {
+		return nil
+	}
+
+	return cq.buckets[cq.find()][0]
+}
+
+// removeMin removes and returns the next event.
+func (cq *calendarQueue) removeMin() *Event {
+	if cq.size == 0 {
+		return nil
+	}
+
+	b := cq.find()
+	ev := cq.buckets[b][0]
+	cq.buckets[b][0] = nil
+	cq.buckets[b] = cq.buckets[b][1:]
+	cq.removed(ev)
+
+	return ev
+}
+
+// remove removes the given event.
+func (cq *calendarQueue) remove(ev *Event) {
+	b := ev.index
+	events := cq.buckets[b]
+	i := sort.Search(len(events), func(i int) bool {
+		return !before(events[i], ev)
+	})
+
+	copy(events[i:], events[i+1:])
+	events[len(events)-1] = nil
+	cq.buckets[b] = events[:len(events)-1]
+	cq.removed(ev)
+}
+
+// removed updates the calendar queue after the given event was removed from
+// its bucket.
+func (cq *calendarQueue) removed(ev *Event) {
+	ev.index = -1
+	cq.size--
+
+	if cq.size < len(cq.buckets)/2 && len(cq.buckets) > minBuckets {
+		cq.resize(len(cq.buckets) / 2)
+	}
+}
+
+// add adds the given event to its bucket without resizing.
+func (cq *calendarQueue) add(ev *Event) {
+	day := math.Floor(ev.time / cq.width)
+	b := cq.bucketOf(day)
+	ev.index = b
+
+	events := cq.buckets[b]
+	i := sort.Search(len(events), func(i int) bool {
+		return before(ev, events[i])
+	})
+
+	events = append(events, nil)
+	copy(events[i+1:], events[i:])
+	events[i] = ev
+	cq.buckets[b] = events
+
+	if day < cq.day {
+		// search for the next event must start at this day
+		cq.day = day
+		cq.bucket = b
+	}
+}
+
+// find returns the bucket containing the next event. At least one event must
+// be contained.
+func (cq *calendarQueue) find() int {
+	// scan one year day by day
+	for i := 0; i < len(cq.buckets); i++ {
+		events := cq.buckets[cq.bucket]
+		if len(events) > 0 && math.Floor(events[0].time/cq.width) <= cq.day {
+			return cq.bucket
+		}
+
+		cq.bucket = (cq.bucket + 1) % len(cq.buckets)
+		cq.day++
+	}
+
+	// no event in the scanned year, search directly
+	best := -1
+	for b, events := range cq.buckets {
+		if len(events) > 0 && (best < 0 || before(events[0], cq.buckets[best][0])) {
+			best = b
+		}
+	}
+
+	cq.bucket = best
+	cq.day = math.Floor(cq.buckets[best][0].time / cq.width)
+	return best
+}
+
+// resize redistributes all events to the given number of buckets with a newly
+// estimated width.
+func (cq *calendarQueue) resize(n int) {
+	if n < minBuckets {
+		n = minBuckets
+	}
+
+	width := cq.estimateWidth()
+
+	var events []*Event
+	for _, bucket := range cq.buckets {
+		events = append(events, bucket...)
+	}
+
+	cq.buckets = make([][]*Event, n)
+	cq.width = width
+	cq.day = math.Inf(1)
+	cq.bucket = 0
+	for _, ev := range events {
+		cq.add(ev)
+	}
+}
+
+// estimateWidth estimates a good width as three times the average separation
+// of the next events, ignoring large separations. Returns the current width if
+// no estimate is possible.
+func (cq *calendarQueue) estimateWidth() float64 {
+	n := cq.size
+	if n > widthSamples {
+		n = widthSamples
+	}
+
+	if n < 2 {
+		return cq.width
+	}
+
+	// remove the next events temporarily to sample their times
+	samples := make([]*Event, n)
+	for i := range samples {
+		b := cq.find()
+		samples[i] = cq.buckets[b][0]
+		cq.buckets[b] = cq.buckets[b][1:]
+	}
+	for _, ev := range samples {
+		cq.add(ev)
+	}
+
+	avg := (samples[n-1].time - samples[0].time) / float64(n-1)
+
+	sum := 0.0
+	count := 0
+	for i := 1; i < n; i++ {
+		if sep := samples[i].time - samples[i-1].time; sep <= 2*avg {
+			sum += sep
+			count++
+		}
+	}
+
+	if count == 0 || sum == 0 || math.IsInf(sum, 0) || math.IsNaN(sum) {
+		return cq.width
+	}
+
+	return 3 * sum / float64(count)
+}
+
+// bucketOf returns the bucket of the given day.
+func (cq *calendarQueue) bucketOf(day float64) int {
+	if math.IsInf(day, 0) {
+		return 0
+	}
+
+	return int(math.Mod(day, float64(len(cq.buckets))))
+}
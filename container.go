@@ -0,0 +1,270 @@
+package simgo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fschuetz04/simgo/stats"
+)
+
+// Container is a resource for storing a continuous amount of matter, like fuel
+// or water. Amounts are put into and retrieved from the container in a
+// first-in first-out order.
+type Container struct {
+	// sim is the reference to the simulation.
+	sim *Simulation
+
+	// gets holds the list of pending get events.
+	gets []*AmountEvent
+
+	// puts holds the list of pending put events.
+	puts []*AmountEvent
+
+	// level is the amount currently in the container.
+	level float64
+
+	// capacity is the maximum amount in the container.
+	capacity float64
+
+	// levels accumulates the level of the container.
+	levels *stats.TimeWeighted
+
+	// getQueue accumulates statistics about the pending get events.
+	getQueue queueMonitor
+
+	// putQueue accumulates statistics about the pending put events.
+	putQueue queueMonitor
+}
+
+// ContainerStats holds statistics about a container since the last reset.
+type ContainerStats struct {
+	// MeanLevel is the time-weighted average level of the container.
+	MeanLevel float64
+
+	// MaxLevel is the maximum level of the container.
+	MaxLevel float64
+
+	// Gets holds statistics about the queue of pending get events.
+	Gets QueueStats
+
+	// Puts holds statistics about the queue of pending put events.
+	Puts QueueStats
+}
+
+// AmountEvent is the event returned from (*Container).Get and
+// (*Container).Put.
+type AmountEvent struct {
+	// Event is the underlying event.
+	*Event
+
+	// amount holds the amount to be retrieved from or put into the container.
+	amount float64
+
+	// requested is the simulation time at which the amount was requested or
+	// offered.
+	requested float64
+
+	// put is whether the amount is put into the container.
+	put bool
+}
+
+// NewContainer creates an empty container for the given simulation with an
+// unlimited capacity.
+func NewContainer(sim *Simulation) *Container {
+	return NewContainerWithLevel(sim, math.Inf(1), 0)
+}
+
+// NewContainerWithCapacity creates an empty container for the given simulation
+// with the given capacity.
+func NewContainerWithCapacity(sim *Simulation, capacity float64) *Container {
+	return NewContainerWithLevel(sim, capacity, 0)
+}
+
+// NewContainerWithLevel creates a container for the given simulation with the
+// given capacity and the given initial level.
+func NewContainerWithLevel(sim *Simulation, capacity float64, level float64) *Container {
+	if capacity <= 0 {
+		panic("NewContainerWithLevel: capacity must be > 0")
+	}
+
+	if level < 0 || level > capacity {
+		panic("NewContainerWithLevel: level must be >= 0 and <= capacity")
+	}
+
+	return &Container{
+		sim:      sim,
+		level:    level,
+		capacity: capacity,
+		levels:   stats.NewTimeWeighted(sim, level),
+		getQueue: newQueueMonitor(sim),
+		putQueue: newQueueMonitor(sim),
+	}
+}
+
+// Capacity returns the capacity of the container.
+func (con *Container) Capacity() float64 {
+	return con.capacity
+}
+
+// Level returns the amount currently in the container.
+func (con *Container) Level() float64 {
+	return con.level
+}
+
+// Stats returns statistics about the container since it was created or since
+// the last call to (*Container).ResetStats.
+func (con *Container) Stats() ContainerStats {
+	return ContainerStats{
+		MeanLevel: con.levels.Mean(),
+		MaxLevel:  con.levels.Max(),
+		Gets:      con.getQueue.stats(),
+		Puts:      con.putQueue.stats(),
+	}
+}
+
+// ResetStats resets the statistics about the container, for example after a
+// warm-up period.
+func (con *Container) ResetStats() {
+	con.levels.Reset()
+	con.getQueue.reset()
+	con.putQueue.reset()
+}
+
+// Amount returns the amount to be retrieved from or put into the container.
+func (ev *AmountEvent) Amount() float64 {
+	return ev.amount
+}
+
+// String returns a description of the get or put.
+func (ev *AmountEvent) String() string {
+	if ev.put {
+		return fmt.Sprintf("put of %f into container", ev.amount)
+	}
+
+	return fmt.Sprintf("get of %f from container", ev.amount)
+}
+
+// Cancel cancels the get or put while it is pending by aborting the underlying
+// event. The get or put is removed from the queue of the container.
+//
+// Panics if the get or put was already triggered.
+func (ev *AmountEvent) Cancel() {
+	if ev.Triggered() {
+		panic("(*AmountEvent).Cancel: get or put was already triggered")
+	}
+
+	ev.Abort()
+}
+
+// Get returns an event that is triggered when the given amount is retrieved
+// from the container, which may be immediately. To cancel a pending get, use
+// (*AmountEvent).Cancel.
+//
+// Panics if the given amount is negative or exceeds the capacity.
+func (con *Container) Get(amount float64) *AmountEvent {
+	if amount < 0 || amount > con.capacity {
+		panic(fmt.Sprintf("(*Container).Get: amount must be >= 0 and <= capacity: %f", amount))
+	}
+
+	ev := &AmountEvent{Event: con.sim.Event(), amount: amount, requested: con.sim.Now()}
+	ev.AddHandler(func(*Event) {
+		// the container has a lower level, so check whether any pending puts
+		// can be triggered
+		con.triggerPuts()
+	})
+	ev.AddAbortHandler(func(*Event) {
+		// the get is cancelled, so remove it and check whether any following
+		// gets can be triggered
+		con.gets = removeEvent(con.gets, ev)
+		con.triggerGets()
+	})
+
+	con.gets = append(con.gets, ev)
+	con.triggerGets()
+
+	return ev
+}
+
+// Put returns an event that is triggered when the given amount is put into the
+// container, which may be immediately. To cancel a pending put, use
+// (*AmountEvent).Cancel.
+//
+// Panics if the given amount is negative or exceeds the capacity.
+func (con *Container) Put(amount float64) *AmountEvent {
+	if amount < 0 || amount > con.capacity {
+		panic(fmt.Sprintf("(*Container).Put: amount must be >= 0 and <= capacity: %f", amount))
+	}
+
+	ev := &AmountEvent{Event: con.sim.Event(), amount: amount, requested: con.sim.Now(), put: true}
+	ev.AddHandler(func(*Event) {
+		// the container has a higher level, so check whether any pending gets
+		// can be triggered
+		con.triggerGets()
+	})
+	ev.AddAbortHandler(func(*Event) {
+		// the put is cancelled, so remove it and check whether any following
+		// puts can be triggered
+		con.puts = removeEvent(con.puts, ev)
+		con.triggerPuts()
+	})
+
+	con.puts = append(con.puts, ev)
+	con.triggerPuts()
+
+	return ev
+}
+
+// triggerGets triggers pending get events until the first pending get event
+// requests more than the current level.
+func (con *Container) triggerGets() {
+	for len(con.gets) > 0 && con.gets[0].amount <= con.level {
+		get := con.gets[0]
+		con.gets = con.gets[1:]
+
+		if !get.Trigger() {
+			continue
+		}
+
+		con.level -= get.amount
+		con.getQueue.serve(get.requested)
+	}
+
+	con.observe()
+}
+
+// triggerPuts triggers pending put events until the first pending put event
+// does not fit into the container.
+func (con *Container) triggerPuts() {
+	for len(con.puts) > 0 && con.puts[0].amount <= con.capacity-con.level {
+		put := con.puts[0]
+		con.puts = con.puts[1:]
+
+		if !put.Trigger() {
+			continue
+		}
+
+		con.level += put.amount
+		con.putQueue.serve(put.requested)
+	}
+
+	con.observe()
+}
+
+// observe updates the statistics with the current state of the container.
+func (con *Container) observe() {
+	con.levels.Update(con.level)
+	con.getQueue.length.Update(float64(len(con.gets)))
+	con.putQueue.length.Update(float64(len(con.puts)))
+}
+
+// removeEvent removes the given event from the given list of events if it is
+// contained and returns the resulting list.
+func removeEvent[E comparable](evs []E, ev E) []E {
+	for i := range evs {
+		if evs[i] == ev {
+			return append(evs[:i], evs[i+1:]...)
+		}
+	}
+
+	return evs
+}
@@ -0,0 +1,201 @@
+package simgo
+
+import "testing"
+
+func TestContainerCapacityZero(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewContainerWithCapacity did not panic with a capacity of 0")
+		}
+	}()
+
+	sim := NewSimulation()
+	NewContainerWithCapacity(sim, 0)
+}
+
+func TestContainerLevelExceedsCapacity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewContainerWithLevel did not panic with a level exceeding the capacity")
+		}
+	}()
+
+	sim := NewSimulation()
+	NewContainerWithLevel(sim, 1, 2)
+}
+
+func TestContainerGetNegative(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("(*Container).Get did not panic with a negative amount")
+		}
+	}()
+
+	sim := NewSimulation()
+	con := NewContainer(sim)
+	con.Get(-1)
+}
+
+func TestContainerCapacityPut(t *testing.T) {
+	sim := NewSimulation()
+	con := NewContainerWithCapacity(sim, 10)
+
+	sim.Process(func(proc Process) {
+		for i := 0; i < 4; i++ {
+			con.Put(3)
+		}
+	})
+
+	sim.Run()
+	assertf(t, con.Capacity() == 10, "con.Capacity() == %f", con.Capacity())
+	assertf(t, con.Level() == 9, "con.Level() == %f", con.Level())
+	assertf(t, len(con.puts) == 1, "len(con.puts) == %d", len(con.puts))
+}
+
+func TestContainerImmediatePut(t *testing.T) {
+	sim := NewSimulation()
+	con := NewContainerWithCapacity(sim, 10)
+	finished := false
+
+	sim.Process(func(proc Process) {
+		// container has enough space, immediate put request
+		put_ev := con.Put(4)
+
+		assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 0, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 4, "con.Level() == %f", con.Level())
+		assertf(t, put_ev.Triggered(), "put_ev.Triggered() == false")
+
+		// container has enough matter, immediate get request
+		get_ev := con.Get(3)
+
+		assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 0, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 1, "con.Level() == %f", con.Level())
+		assertf(t, get_ev.Triggered(), "get_ev.Triggered() == false")
+
+		// container has not enough matter, get request queued
+		get_ev = con.Get(2)
+
+		assertf(t, len(con.gets) == 1, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 0, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 1, "con.Level() == %f", con.Level())
+		assertf(t, !get_ev.Triggered(), "get_ev.Triggered() == true")
+
+		// container has enough space, immediate put request
+		put_ev = con.Put(1)
+
+		assertf(t, len(con.gets) == 1, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 0, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 2, "con.Level() == %f", con.Level())
+		assertf(t, put_ev.Triggered(), "put_ev.Triggered() == false")
+
+		// get request will now be triggered
+		proc.Wait(get_ev)
+
+		assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 0, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 0, "con.Level() == %f", con.Level())
+
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestContainerImmediateGet(t *testing.T) {
+	sim := NewSimulation()
+	con := NewContainerWithLevel(sim, 10, 8)
+	finished := false
+
+	sim.Process(func(proc Process) {
+		// container has not enough space, put request queued
+		put_ev := con.Put(5)
+
+		assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 1, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 8, "con.Level() == %f", con.Level())
+		assertf(t, !put_ev.Triggered(), "put_ev.Triggered() == true")
+
+		// container has enough matter, immediate get request
+		get_ev := con.Get(4)
+
+		assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 1, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 4, "con.Level() == %f", con.Level())
+		assertf(t, get_ev.Triggered(), "get_ev.Triggered() == false")
+
+		// put request will now be triggered
+		proc.Wait(put_ev)
+
+		assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+		assertf(t, len(con.puts) == 0, "len(con.puts) == %d", len(con.puts))
+		assertf(t, con.Level() == 9, "con.Level() == %f", con.Level())
+
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestContainerAbortGet(t *testing.T) {
+	sim := NewSimulation()
+	con := NewContainerWithLevel(sim, 10, 2)
+	finished := false
+
+	sim.Process(func(proc Process) {
+		// container has not enough matter, both get requests queued
+		get_ev1 := con.Get(5)
+		get_ev2 := con.Get(1)
+
+		assertf(t, len(con.gets) == 2, "len(con.gets) == %d", len(con.gets))
+		assertf(t, !get_ev2.Triggered(), "get_ev2.Triggered() == true")
+
+		// first get request is cancelled, so the second one is triggered
+		get_ev1.Abort()
+
+		assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+		assertf(t, con.Level() == 1, "con.Level() == %f", con.Level())
+		assertf(t, get_ev2.Triggered(), "get_ev2.Triggered() == false")
+
+		proc.Wait(get_ev2)
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestContainerCancel(t *testing.T) {
+	sim := NewSimulation()
+	con := NewContainerWithCapacity(sim, 10)
+
+	get := con.Get(5)
+	get.Cancel()
+	assertf(t, get.Aborted(), "get.Aborted() == false")
+	assertf(t, len(con.gets) == 0, "len(con.gets) == %d", len(con.gets))
+
+	// the cancelled get does not consume the amount put later
+	con.Put(5)
+	sim.Run()
+	assertf(t, con.Level() == 5, "con.Level() == %f", con.Level())
+
+	put := con.Put(10)
+	put.Cancel()
+	assertf(t, len(con.puts) == 0, "len(con.puts) == %d", len(con.puts))
+	sim.Run()
+	assertf(t, con.Level() == 5, "con.Level() == %f", con.Level())
+}
+
+func TestContainerCancelTriggered(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := NewSimulation()
+	con := NewContainerWithLevel(sim, 10, 5)
+	con.Get(5).Cancel()
+}
@@ -0,0 +1,91 @@
+package simgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlockedProcess describes a process which is waiting for an awaitable.
+type BlockedProcess struct {
+	// Process is the waiting process.
+	Process Process
+
+	// Awaiting is the awaitable the process is waiting for.
+	Awaiting Awaitable
+
+	// Since is the simulation time at which the process started waiting.
+	Since float64
+}
+
+// String returns a description of the blocked process and the awaitable it is
+// waiting for.
+func (blocked BlockedProcess) String() string {
+	return fmt.Sprintf("%s started at %f waiting since %f for %s", blocked.Process, blocked.Process.Started(), blocked.Since, describe(blocked.Awaiting))
+}
+
+// DeadlockError is returned when the event queue of a simulation is empty while
+// processes are still waiting. These processes can never be resumed, unless
+// events are triggered from outside of the simulation.
+type DeadlockError struct {
+	// Now is the simulation time at which the deadlock was detected.
+	Now float64
+
+	// Blocked holds the waiting processes in the order they were started.
+	Blocked []BlockedProcess
+}
+
+// Error returns a description of the deadlock listing all waiting processes.
+func (err *DeadlockError) Error() string {
+	descriptions := make([]string, len(err.Blocked))
+	for i, blocked := range err.Blocked {
+		descriptions[i] = blocked.String()
+	}
+
+	return fmt.Sprintf("deadlock at %f with %d blocked processes: %s", err.Now, len(err.Blocked), strings.Join(descriptions, "; "))
+}
+
+// Blocked returns all processes which are waiting for an awaitable in the
+// order they were started.
+func (sim *Simulation) Blocked() []BlockedProcess {
+	var blocked []BlockedProcess
+	for _, proc := range sim.Processes() {
+		if proc.data.awaiting != nil {
+			blocked = append(blocked, BlockedProcess{Process: proc, Awaiting: proc.data.awaiting, Since: proc.data.since})
+		}
+	}
+
+	return blocked
+}
+
+// CheckDeadlock returns a *DeadlockError if the event queue is empty while
+// processes are still waiting, for example for a request for a resource which
+// is never released or a get from a store without producer. Returns nil
+// otherwise.
+//
+// Call this after (*Simulation).Run to detect stuck processes:
+//
+//	sim.Run()
+//	if err := sim.CheckDeadlock(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (sim *Simulation) CheckDeadlock() error {
+	if sim.events.len() > 0 {
+		return nil
+	}
+
+	blocked := sim.Blocked()
+	if len(blocked) == 0 {
+		return nil
+	}
+
+	return &DeadlockError{Now: sim.Now(), Blocked: blocked}
+}
+
+// describe returns a description of the given awaitable.
+func describe(ev Awaitable) string {
+	if stringer, ok := ev.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	return fmt.Sprintf("%T", ev)
+}
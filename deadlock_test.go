@@ -0,0 +1,107 @@
+package simgo_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fschuetz04/simgo"
+)
+
+func TestCheckDeadlockResource(t *testing.T) {
+	sim := simgo.NewSimulation()
+	res := simgo.NewResource(sim, 1)
+
+	sim.Process(func(proc simgo.Process) {
+		// never released
+		proc.Wait(res.Request())
+		proc.Wait(proc.Timeout(1))
+	})
+
+	var req *simgo.Request
+	sim.Process(func(proc simgo.Process) {
+		req = res.Request()
+		proc.Wait(req)
+	})
+
+	sim.Run()
+
+	err := sim.CheckDeadlock()
+	var deadlock *simgo.DeadlockError
+	assertf(t, errors.As(err, &deadlock), "err == %v", err)
+	assertf(t, len(deadlock.Blocked) == 1, "len(deadlock.Blocked) == %d", len(deadlock.Blocked))
+	assertf(t, deadlock.Blocked[0].Awaiting == simgo.Awaitable(req), "deadlock.Blocked[0].Awaiting == %v", deadlock.Blocked[0].Awaiting)
+	assertf(t, deadlock.Blocked[0].Since == 0, "deadlock.Blocked[0].Since == %f", deadlock.Blocked[0].Since)
+	assertf(t, deadlock.Now == 1, "deadlock.Now == %f", deadlock.Now)
+	assertf(t, strings.Contains(err.Error(), "request with priority 0"), "err == %v", err)
+}
+
+func TestCheckDeadlockStore(t *testing.T) {
+	sim := simgo.NewSimulation()
+	store := simgo.NewStore[int](sim)
+
+	for i := 0; i < 2; i++ {
+		sim.Process(func(proc simgo.Process) {
+			proc.Wait(proc.Timeout(2))
+			proc.Wait(store.Get())
+		})
+	}
+
+	res := sim.RunContext(context.Background())
+	assertf(t, res.Reason == simgo.QueueEmpty, "res.Reason == %v", res.Reason)
+
+	var deadlock *simgo.DeadlockError
+	assertf(t, errors.As(res.Err, &deadlock), "res.Err == %v", res.Err)
+	assertf(t, len(deadlock.Blocked) == 2, "len(deadlock.Blocked) == %d", len(deadlock.Blocked))
+	assertf(t, deadlock.Blocked[0].Since == 2, "deadlock.Blocked[0].Since == %f", deadlock.Blocked[0].Since)
+	assertf(t, strings.Contains(res.Err.Error(), "get from store"), "res.Err == %v", res.Err)
+
+	// a put resolves the deadlock of one process
+	store.Put(1)
+	sim.Run()
+	blocked := sim.Blocked()
+	assertf(t, len(blocked) == 1, "len(blocked) == %d", len(blocked))
+}
+
+func TestCheckDeadlockNone(t *testing.T) {
+	sim := simgo.NewSimulation()
+	ev := sim.Event()
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(ev)
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(5))
+		ev.Trigger()
+	})
+
+	sim.RunUntil(3)
+	// the queue is not empty
+	assertf(t, sim.CheckDeadlock() == nil, "sim.CheckDeadlock() == %v", sim.CheckDeadlock())
+	assertf(t, len(sim.Blocked()) == 2, "len(sim.Blocked()) == %d", len(sim.Blocked()))
+
+	res := sim.RunContext(context.Background())
+	assertf(t, res.Err == nil, "res.Err == %v", res.Err)
+	assertf(t, len(sim.Blocked()) == 0, "len(sim.Blocked()) == %d", len(sim.Blocked()))
+}
+
+func TestCheckDeadlockProcess(t *testing.T) {
+	sim := simgo.NewSimulation()
+	ev := sim.Event()
+
+	waiter := sim.Process(func(proc simgo.Process) {
+		proc.Wait(ev)
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(waiter)
+	})
+
+	sim.Run()
+
+	err := sim.CheckDeadlock()
+	assertf(t, err != nil, "err == nil")
+	assertf(t, strings.Contains(err.Error(), "for process 0"), "err == %v", err)
+}
@@ -0,0 +1,454 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+)
+
+// Uniform is the continuous uniform distribution between a minimum and a
+// maximum.
+type Uniform struct {
+	// src is the source of random numbers.
+	src Source
+
+	// min is the minimum.
+	min float64
+
+	// max is the maximum.
+	max float64
+}
+
+// NewUniform creates a uniform distribution between the given minimum and
+// maximum. Panics if the maximum is smaller than the minimum.
+func NewUniform(src Source, min float64, max float64) *Uniform {
+	if max < min {
+		panic(fmt.Sprintf("NewUniform: max must be >= min: %f < %f", max, min))
+	}
+
+	return &Uniform{src: src, min: min, max: max}
+}
+
+// Sample returns a random variate.
+func (d *Uniform) Sample() float64 {
+	return d.min + (d.max-d.min)*d.src.Float64()
+}
+
+// Exponential is the exponential distribution with a given mean.
+type Exponential struct {
+	// src is the source of random numbers.
+	src Source
+
+	// mean is the mean.
+	mean float64
+}
+
+// NewExponential creates an exponential distribution with the given mean.
+// Panics if the mean is not positive.
+func NewExponential(src Source, mean float64) *Exponential {
+	if mean <= 0 {
+		panic(fmt.Sprintf("NewExponential: mean must be > 0: %f", mean))
+	}
+
+	return &Exponential{src: src, mean: mean}
+}
+
+// Sample returns a random variate.
+func (d *Exponential) Sample() float64 {
+	return -d.mean * math.Log(uniform(d.src))
+}
+
+// Normal is the normal distribution with a given mean and standard deviation.
+type Normal struct {
+	// src is the source of random numbers.
+	src Source
+
+	// mean is the mean.
+	mean float64
+
+	// stdDev is the standard deviation.
+	stdDev float64
+}
+
+// NewNormal creates a normal distribution with the given mean and standard
+// deviation. Panics if the standard deviation is negative.
+func NewNormal(src Source, mean float64, stdDev float64) *Normal {
+	if stdDev < 0 {
+		panic(fmt.Sprintf("NewNormal: stdDev must be >= 0: %f", stdDev))
+	}
+
+	return &Normal{src: src, mean: mean, stdDev: stdDev}
+}
+
+// Sample returns a random variate.
+func (d *Normal) Sample() float64 {
+	return d.mean + d.stdDev*standardNormal(d.src)
+}
+
+// TruncatedNormal is the normal distribution with a given mean and standard
+// deviation restricted to the interval between a lower and an upper bound.
+type TruncatedNormal struct {
+	// src is the source of random numbers.
+	src Source
+
+	// mean is the mean of the untruncated distribution.
+	mean float64
+
+	// stdDev is the standard deviation of the untruncated distribution.
+	stdDev float64
+
+	// mirrored is whether the interval lies above the mean and is mirrored
+	// at the mean, so the CDF is evaluated in the lower tail, where it is
+	// precise even far away from the mean.
+	mirrored bool
+
+	// lower is the standard normal CDF at the lower bound of the standardized
+	// and possibly mirrored interval.
+	lower float64
+
+	// upper is the standard normal CDF at the upper bound of the standardized
+	// and possibly mirrored interval.
+	upper float64
+}
+
+// NewTruncatedNormal creates a normal distribution with the given mean and
+// standard deviation truncated to the interval between the given lower and
+// upper bound, which may be infinite. This is useful for durations, which must
+// not be negative.
+//
+// Panics if the standard deviation is not positive or the bounds do not
+// contain any probability mass.
+func NewTruncatedNormal(src Source, mean float64, stdDev float64, lower float64, upper float64) *TruncatedNormal {
+	if stdDev <= 0 {
+		panic(fmt.Sprintf("NewTruncatedNormal: stdDev must be > 0: %f", stdDev))
+	}
+
+	a, b := (lower-mean)/stdDev, (upper-mean)/stdDev
+	mirrored := a > 0
+	if mirrored {
+		a, b = -b, -a
+	}
+
+	d := &TruncatedNormal{
+		src:      src,
+		mean:     mean,
+		stdDev:   stdDev,
+		mirrored: mirrored,
+		lower:    standardNormalCDF(a),
+		upper:    standardNormalCDF(b),
+	}
+
+	if d.lower >= d.upper {
+		panic(fmt.Sprintf("NewTruncatedNormal: interval [%f, %f] has no probability mass", lower, upper))
+	}
+
+	return d
+}
+
+// Sample returns a random variate using the inverse transform method.
+func (d *TruncatedNormal) Sample() float64 {
+	p := d.lower + (d.upper-d.lower)*uniform(d.src)
+	x := standardNormalQuantile(p)
+	if d.mirrored {
+		x = -x
+	}
+
+	return d.mean + d.stdDev*x
+}
+
+// LogNormal is the log-normal distribution, which is the distribution of a
+// random variate whose logarithm is normally distributed.
+type LogNormal struct {
+	// src is the source of random numbers.
+	src Source
+
+	// mu is the mean of the logarithm.
+	mu float64
+
+	// sigma is the standard deviation of the logarithm.
+	sigma float64
+}
+
+// NewLogNormal creates a log-normal distribution whose logarithm has the given
+// mean mu and standard deviation sigma. Panics if sigma is negative.
+func NewLogNormal(src Source, mu float64, sigma float64) *LogNormal {
+	if sigma < 0 {
+		panic(fmt.Sprintf("NewLogNormal: sigma must be >= 0: %f", sigma))
+	}
+
+	return &LogNormal{src: src, mu: mu, sigma: sigma}
+}
+
+// Sample returns a random variate.
+func (d *LogNormal) Sample() float64 {
+	return math.Exp(d.mu + d.sigma*standardNormal(d.src))
+}
+
+// Triangular is the triangular distribution between a minimum and a maximum
+// with a given mode.
+type Triangular struct {
+	// src is the source of random numbers.
+	src Source
+
+	// min is the minimum.
+	min float64
+
+	// mode is the mode.
+	mode float64
+
+	// max is the maximum.
+	max float64
+}
+
+// NewTriangular creates a triangular distribution between the given minimum
+// and maximum with the given mode. Panics unless min <= mode <= max and
+// min < max.
+func NewTriangular(src Source, min float64, mode float64, max float64) *Triangular {
+	if min > mode || mode > max || min >= max {
+		panic(fmt.Sprintf("NewTriangular: min <= mode <= max and min < max must hold: %f, %f, %f", min, mode, max))
+	}
+
+	return &Triangular{src: src, min: min, mode: mode, max: max}
+}
+
+// Sample returns a random variate using the inverse transform method.
+func (d *Triangular) Sample() float64 {
+	u := d.src.Float64()
+	split := (d.mode - d.min) / (d.max - d.min)
+
+	if u < split {
+		return d.min + math.Sqrt(u*(d.max-d.min)*(d.mode-d.min))
+	}
+
+	return d.max - math.Sqrt((1-u)*(d.max-d.min)*(d.max-d.mode))
+}
+
+// Erlang is the Erlang distribution, which is the distribution of the sum of a
+// number of independent exponentially distributed phases.
+type Erlang struct {
+	// src is the source of random numbers.
+	src Source
+
+	// k is the number of phases.
+	k int
+
+	// mean is the mean of the sum.
+	mean float64
+}
+
+// NewErlang creates an Erlang distribution with the given number of phases k
+// and the given mean of their sum. Panics if k or the mean is not positive.
+func NewErlang(src Source, k int, mean float64) *Erlang {
+	if k <= 0 {
+		panic(fmt.Sprintf("NewErlang: k must be > 0: %d", k))
+	}
+
+	if mean <= 0 {
+		panic(fmt.Sprintf("NewErlang: mean must be > 0: %f", mean))
+	}
+
+	return &Erlang{src: src, k: k, mean: mean}
+}
+
+// Sample returns a random variate.
+func (d *Erlang) Sample() float64 {
+	// the sum of logarithms is used instead of the logarithm of a product to
+	// avoid an underflow for many phases
+	sum := 0.0
+	for i := 0; i < d.k; i++ {
+		sum += math.Log(uniform(d.src))
+	}
+
+	return -d.mean / float64(d.k) * sum
+}
+
+// Gamma is the gamma distribution with a given shape and scale.
+type Gamma struct {
+	// src is the source of random numbers.
+	src Source
+
+	// shape is the shape parameter.
+	shape float64
+
+	// scale is the scale parameter.
+	scale float64
+}
+
+// NewGamma creates a gamma distribution with the given shape and scale. The
+// mean is shape * scale. Panics if the shape or the scale is not positive.
+func NewGamma(src Source, shape float64, scale float64) *Gamma {
+	if shape <= 0 {
+		panic(fmt.Sprintf("NewGamma: shape must be > 0: %f", shape))
+	}
+
+	if scale <= 0 {
+		panic(fmt.Sprintf("NewGamma: scale must be > 0: %f", scale))
+	}
+
+	return &Gamma{src: src, shape: shape, scale: scale}
+}
+
+// Sample returns a random variate.
+func (d *Gamma) Sample() float64 {
+	return d.scale * standardGamma(d.src, d.shape)
+}
+
+// Weibull is the Weibull distribution with a given shape and scale.
+type Weibull struct {
+	// src is the source of random numbers.
+	src Source
+
+	// shape is the shape parameter.
+	shape float64
+
+	// scale is the scale parameter.
+	scale float64
+}
+
+// NewWeibull creates a Weibull distribution with the given shape and scale.
+// Panics if the shape or the scale is not positive.
+func NewWeibull(src Source, shape float64, scale float64) *Weibull {
+	if shape <= 0 {
+		panic(fmt.Sprintf("NewWeibull: shape must be > 0: %f", shape))
+	}
+
+	if scale <= 0 {
+		panic(fmt.Sprintf("NewWeibull: scale must be > 0: %f", scale))
+	}
+
+	return &Weibull{src: src, shape: shape, scale: scale}
+}
+
+// Sample returns a random variate using the inverse transform method.
+func (d *Weibull) Sample() float64 {
+	return d.scale * math.Pow(-math.Log(uniform(d.src)), 1/d.shape)
+}
+
+// Beta is the beta distribution on the interval [0, 1] with the given shape
+// parameters alpha and beta.
+type Beta struct {
+	// src is the source of random numbers.
+	src Source
+
+	// alpha is the first shape parameter.
+	alpha float64
+
+	// beta is the second shape parameter.
+	beta float64
+}
+
+// NewBeta creates a beta distribution with the given shape parameters. Panics
+// if alpha or beta is not positive.
+func NewBeta(src Source, alpha float64, beta float64) *Beta {
+	if alpha <= 0 || beta <= 0 {
+		panic(fmt.Sprintf("NewBeta: alpha and beta must be > 0: %f, %f", alpha, beta))
+	}
+
+	return &Beta{src: src, alpha: alpha, beta: beta}
+}
+
+// Sample returns a random variate.
+func (d *Beta) Sample() float64 {
+	x := standardGamma(d.src, d.alpha)
+	y := standardGamma(d.src, d.beta)
+	return x / (x + y)
+}
+
+// standardNormal returns a standard normal random variate using the Box-Muller
+// transform.
+func standardNormal(src Source) float64 {
+	u1 := uniform(src)
+	u2 := src.Float64()
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
+
+// standardNormalCDF returns the cumulative distribution function of the
+// standard normal distribution at x.
+func standardNormalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// standardNormalQuantile returns the inverse of the cumulative distribution
+// function of the standard normal distribution at p using algorithm AS 241 by
+// Wichura, which is precise even for tiny probabilities. Returns -Inf for p <= 0
+// and +Inf for p >= 1.
+func standardNormalQuantile(p float64) float64 {
+	if p <= 0 {
+		return math.Inf(-1)
+	}
+
+	if p >= 1 {
+		return math.Inf(1)
+	}
+
+	q := p - 0.5
+	if math.Abs(q) <= 0.425 {
+		r := 0.180625 - q*q
+		num := ((((((2509.0809287301226727*r+33430.575583588128105)*r+67265.770927008700853)*r+
+			45921.953931549871457)*r+13731.693765509461125)*r+1971.5909503065514427)*r+
+			133.14166789178437745)*r + 3.387132872796366608
+		den := ((((((5226.495278852545925*r+28729.085735721942674)*r+39307.89580009271061)*r+
+			21213.794301586595867)*r+5394.1960214247511077)*r+687.1870074920579083)*r+
+			42.313330701600911252)*r + 1
+		return q * num / den
+	}
+
+	// distance from the nearer tail
+	r := p
+	if q > 0 {
+		r = 1 - p
+	}
+	r = math.Sqrt(-math.Log(r))
+
+	var x float64
+	if r <= 5 {
+		r -= 1.6
+		num := ((((((7.7454501427834140764e-4*r+0.0227238449892691845833)*r+0.24178072517745061177)*r+
+			1.27045825245236838258)*r+3.64784832476320460504)*r+5.7694972214606914055)*r+
+			4.6303378461565452959)*r + 1.42343711074968357734
+		den := ((((((1.05075007164441684324e-9*r+5.475938084995344946e-4)*r+0.0151986665636164571966)*r+
+			0.14810397642748007459)*r+0.68976733498510000455)*r+1.6763848301838038494)*r+
+			2.05319162663775882187)*r + 1
+		x = num / den
+	} else {
+		r -= 5
+		num := ((((((2.01033439929228813265e-7*r+2.71155556874348757815e-5)*r+0.0012426609473880784386)*r+
+			0.026532189526576123093)*r+0.29656057182850489123)*r+1.7848265399172913358)*r+
+			5.4637849111641143699)*r + 6.6579046435011037772
+		den := ((((((2.04426310338993978564e-15*r+1.4215117583164458887e-7)*r+1.8463183175100546818e-5)*r+
+			7.868691311456132591e-4)*r+0.0148753612908506148525)*r+0.13692988092273580531)*r+
+			0.59983220655588793769)*r + 1
+		x = num / den
+	}
+
+	if q < 0 {
+		return -x
+	}
+
+	return x
+}
+
+// standardGamma returns a gamma random variate with the given shape and scale 1
+// using the method of Marsaglia and Tsang.
+func standardGamma(src Source, shape float64) float64 {
+	if shape < 1 {
+		// boost the shape and correct the result
+		return standardGamma(src, shape+1) * math.Pow(uniform(src), 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+
+	for {
+		x := standardNormal(src)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+
+		v = v * v * v
+		u := uniform(src)
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
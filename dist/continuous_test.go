@@ -0,0 +1,126 @@
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUniform(t *testing.T) {
+	tally := sample(NewUniform(newSource(), 2, 6))
+	assertf(t, near(tally.Mean(), 4, 0.02), "tally.Mean() == %f", tally.Mean())
+	assertf(t, near(tally.Variance(), 16.0/12, 0.02), "tally.Variance() == %f", tally.Variance())
+	assertf(t, tally.Min() >= 2, "tally.Min() == %f", tally.Min())
+	assertf(t, tally.Max() <= 6, "tally.Max() == %f", tally.Max())
+}
+
+func TestExponential(t *testing.T) {
+	tally := sample(NewExponential(newSource(), 5))
+	assertf(t, near(tally.Mean(), 5, 0.05), "tally.Mean() == %f", tally.Mean())
+	assertf(t, near(tally.Variance(), 25, 0.5), "tally.Variance() == %f", tally.Variance())
+	assertf(t, tally.Min() >= 0, "tally.Min() == %f", tally.Min())
+}
+
+func TestExponentialInvalid(t *testing.T) {
+	defer func() {
+		assertf(t, recover() != nil, "NewExponential did not panic")
+	}()
+
+	NewExponential(newSource(), 0)
+}
+
+func TestNormal(t *testing.T) {
+	tally := sample(NewNormal(newSource(), 10, 2))
+	assertf(t, near(tally.Mean(), 10, 0.02), "tally.Mean() == %f", tally.Mean())
+	assertf(t, near(tally.StdDev(), 2, 0.02), "tally.StdDev() == %f", tally.StdDev())
+}
+
+func TestTruncatedNormal(t *testing.T) {
+	tally := sample(NewTruncatedNormal(newSource(), 0, 1, 0, math.Inf(1)))
+	// mean of the half-normal distribution
+	assertf(t, near(tally.Mean(), math.Sqrt(2/math.Pi), 0.01), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() >= 0, "tally.Min() == %f", tally.Min())
+
+	tally = sample(NewTruncatedNormal(newSource(), 5, 3, 4, 6))
+	assertf(t, near(tally.Mean(), 5, 0.01), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() >= 4, "tally.Min() == %f", tally.Min())
+	assertf(t, tally.Max() <= 6, "tally.Max() == %f", tally.Max())
+
+	// far in the upper tail, the mean is slightly above the lower bound
+	tally = sample(NewTruncatedNormal(newSource(), 0, 1, 9, math.Inf(1)))
+	assertf(t, near(tally.Mean(), 9.108, 0.01), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() >= 9, "tally.Min() == %f", tally.Min())
+
+	tally = sample(NewTruncatedNormal(newSource(), 0, 1, math.Inf(-1), -9))
+	assertf(t, near(tally.Mean(), -9.108, 0.01), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Max() <= -9, "tally.Max() == %f", tally.Max())
+}
+
+func TestTruncatedNormalInvalid(t *testing.T) {
+	defer func() {
+		assertf(t, recover() != nil, "NewTruncatedNormal did not panic")
+	}()
+
+	NewTruncatedNormal(newSource(), 0, 1, 2, 1)
+}
+
+func TestStandardNormalQuantile(t *testing.T) {
+	assertf(t, near(standardNormalQuantile(0.975), 1.959963984540054, 1e-12), "standardNormalQuantile(0.975) == %f", standardNormalQuantile(0.975))
+	assertf(t, standardNormalQuantile(0.5) == 0, "standardNormalQuantile(0.5) == %f", standardNormalQuantile(0.5))
+
+	for _, p := range []float64{1e-300, 1e-19, 1e-5, 0.1, 0.7, 1 - 1e-10} {
+		x := standardNormalQuantile(p)
+		assertf(t, near(standardNormalCDF(x)/p, 1, 1e-9), "standardNormalCDF(standardNormalQuantile(%g)) == %g", p, standardNormalCDF(x))
+	}
+}
+
+func TestLogNormal(t *testing.T) {
+	tally := sample(NewLogNormal(newSource(), 1, 0.5))
+	expected := math.Exp(1 + 0.5*0.5/2)
+	assertf(t, near(tally.Mean(), expected, 0.02), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() > 0, "tally.Min() == %f", tally.Min())
+}
+
+func TestTriangular(t *testing.T) {
+	tally := sample(NewTriangular(newSource(), 1, 2, 6))
+	assertf(t, near(tally.Mean(), 3, 0.02), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() >= 1, "tally.Min() == %f", tally.Min())
+	assertf(t, tally.Max() <= 6, "tally.Max() == %f", tally.Max())
+}
+
+func TestTriangularInvalid(t *testing.T) {
+	defer func() {
+		assertf(t, recover() != nil, "NewTriangular did not panic")
+	}()
+
+	NewTriangular(newSource(), 1, 7, 6)
+}
+
+func TestErlang(t *testing.T) {
+	tally := sample(NewErlang(newSource(), 3, 6))
+	assertf(t, near(tally.Mean(), 6, 0.05), "tally.Mean() == %f", tally.Mean())
+	// variance is k * (mean / k)^2
+	assertf(t, near(tally.Variance(), 12, 0.3), "tally.Variance() == %f", tally.Variance())
+}
+
+func TestGamma(t *testing.T) {
+	tally := sample(NewGamma(newSource(), 2.5, 2))
+	assertf(t, near(tally.Mean(), 5, 0.05), "tally.Mean() == %f", tally.Mean())
+	assertf(t, near(tally.Variance(), 10, 0.3), "tally.Variance() == %f", tally.Variance())
+
+	tally = sample(NewGamma(newSource(), 0.5, 1))
+	assertf(t, near(tally.Mean(), 0.5, 0.01), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() >= 0, "tally.Min() == %f", tally.Min())
+}
+
+func TestWeibull(t *testing.T) {
+	tally := sample(NewWeibull(newSource(), 2, 3))
+	expected := 3 * math.Gamma(1+1.0/2)
+	assertf(t, near(tally.Mean(), expected, 0.02), "tally.Mean() == %f", tally.Mean())
+}
+
+func TestBeta(t *testing.T) {
+	tally := sample(NewBeta(newSource(), 2, 6))
+	assertf(t, near(tally.Mean(), 0.25, 0.005), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() >= 0, "tally.Min() == %f", tally.Min())
+	assertf(t, tally.Max() <= 1, "tally.Max() == %f", tally.Max())
+}
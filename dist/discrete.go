@@ -0,0 +1,131 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Poisson is the Poisson distribution with a given mean. Its random variates
+// are non-negative integers.
+type Poisson struct {
+	// src is the source of random numbers.
+	src Source
+
+	// mean is the mean.
+	mean float64
+}
+
+// NewPoisson creates a Poisson distribution with the given mean. Panics if the
+// mean is not positive.
+func NewPoisson(src Source, mean float64) *Poisson {
+	if mean <= 0 {
+		panic(fmt.Sprintf("NewPoisson: mean must be > 0: %f", mean))
+	}
+
+	return &Poisson{src: src, mean: mean}
+}
+
+// Sample returns a random variate. For small means, the multiplication method
+// by Knuth is used. For large means, the transformed rejection method by
+// Hörmann (PTRS) is used.
+func (d *Poisson) Sample() float64 {
+	if d.mean < 10 {
+		limit := math.Exp(-d.mean)
+		k := 0.0
+		for p := uniform(d.src); p > limit; p *= uniform(d.src) {
+			k++
+		}
+		return k
+	}
+
+	smu := math.Sqrt(d.mean)
+	b := 0.931 + 2.53*smu
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	logMean := math.Log(d.mean)
+
+	for {
+		u := d.src.Float64() - 0.5
+		v := uniform(d.src)
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + d.mean + 0.43)
+
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -d.mean+k*logMean-lg {
+			return k
+		}
+	}
+}
+
+// Discrete is a discrete distribution over a finite set of values with given
+// weights.
+type Discrete struct {
+	// src is the source of random numbers.
+	src Source
+
+	// values holds the possible values.
+	values []float64
+
+	// cumulative holds the cumulative weights of the values.
+	cumulative []float64
+}
+
+// NewDiscrete creates a discrete distribution returning each of the given
+// values with a probability proportional to its weight. Panics if the number
+// of values and weights differ, no values are given, any weight is negative or
+// all weights are zero.
+func NewDiscrete(src Source, values []float64, weights []float64) *Discrete {
+	if len(values) != len(weights) {
+		panic(fmt.Sprintf("NewDiscrete: number of values and weights must be equal: %d != %d", len(values), len(weights)))
+	}
+
+	if len(values) == 0 {
+		panic("NewDiscrete: values must not be empty")
+	}
+
+	cumulative := make([]float64, len(weights))
+	sum := 0.0
+	for i, weight := range weights {
+		if weight < 0 {
+			panic(fmt.Sprintf("NewDiscrete: weights must not be negative: %f", weight))
+		}
+
+		sum += weight
+		cumulative[i] = sum
+	}
+
+	if sum == 0 {
+		panic("NewDiscrete: sum of weights must be > 0")
+	}
+
+	return &Discrete{
+		src:        src,
+		values:     append([]float64(nil), values...),
+		cumulative: cumulative,
+	}
+}
+
+// Sample returns a random variate.
+func (d *Discrete) Sample() float64 {
+	target := d.src.Float64() * d.cumulative[len(d.cumulative)-1]
+	i := sort.Search(len(d.cumulative), func(i int) bool {
+		return d.cumulative[i] > target
+	})
+
+	// guard against rounding errors
+	if i == len(d.values) {
+		i--
+	}
+
+	return d.values[i]
+}
@@ -0,0 +1,45 @@
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPoisson(t *testing.T) {
+	for _, mean := range []float64{0.5, 4, 25, 500} {
+		tally := sample(NewPoisson(newSource(), mean))
+		tolerance := 0.02 * math.Max(1, math.Sqrt(mean))
+		assertf(t, near(tally.Mean(), mean, tolerance), "mean == %f, tally.Mean() == %f", mean, tally.Mean())
+		assertf(t, near(tally.Variance(), mean, 0.05*mean+0.02), "mean == %f, tally.Variance() == %f", mean, tally.Variance())
+		assertf(t, tally.Min() >= 0, "mean == %f, tally.Min() == %f", mean, tally.Min())
+	}
+}
+
+func TestPoissonIntegers(t *testing.T) {
+	d := NewPoisson(newSource(), 50)
+	for i := 0; i < 1000; i++ {
+		x := d.Sample()
+		assertf(t, x == math.Floor(x), "x == %f", x)
+	}
+}
+
+func TestDiscrete(t *testing.T) {
+	d := NewDiscrete(newSource(), []float64{1, 2, 3}, []float64{1, 0, 3})
+
+	counts := map[float64]int{}
+	for i := 0; i < samples; i++ {
+		counts[d.Sample()]++
+	}
+
+	assertf(t, counts[2] == 0, "counts[2] == %d", counts[2])
+	share := float64(counts[3]) / samples
+	assertf(t, near(share, 0.75, 0.01), "share == %f", share)
+}
+
+func TestDiscreteInvalid(t *testing.T) {
+	defer func() {
+		assertf(t, recover() != nil, "NewDiscrete did not panic")
+	}()
+
+	NewDiscrete(newSource(), []float64{1, 2}, []float64{0, 0})
+}
@@ -0,0 +1,33 @@
+// Package dist provides probability distributions for sampling random variates
+// like interarrival times, service times or demands.
+//
+// Each distribution samples from a Source, which is usually a random stream of
+// a simulation:
+//
+//	arrivals := dist.NewExponential(sim.Stream("arrivals"), 10)
+//	proc.Wait(proc.Timeout(arrivals.Sample()))
+package dist
+
+// Source provides uniformly distributed random numbers. *simgo.Stream and
+// *rand.Rand implement Source.
+type Source interface {
+	// Float64 must return a random number in the interval [0, 1).
+	Float64() float64
+}
+
+// Distribution is a probability distribution from which random variates can be
+// sampled.
+type Distribution interface {
+	// Sample must return a random variate.
+	Sample() float64
+}
+
+// uniform returns a random number in the open interval (0, 1) from the given
+// source, which can be safely passed to math.Log.
+func uniform(src Source) float64 {
+	for {
+		if u := src.Float64(); u > 0 {
+			return u
+		}
+	}
+}
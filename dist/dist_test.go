@@ -0,0 +1,59 @@
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/fschuetz04/simgo"
+	"github.com/fschuetz04/simgo/stats"
+)
+
+const samples = 100000
+
+func assertf(t *testing.T, condition bool, format string, args ...any) {
+	t.Helper()
+	if !condition {
+		t.Errorf(format, args...)
+	}
+}
+
+func newSource() Source {
+	return rand.New(rand.NewSource(42))
+}
+
+func sample(d Distribution) *stats.Tally {
+	tally := &stats.Tally{}
+	for i := 0; i < samples; i++ {
+		tally.Add(d.Sample())
+	}
+	return tally
+}
+
+func near(x float64, expected float64, tolerance float64) bool {
+	return math.Abs(x-expected) <= tolerance
+}
+
+func TestStreamSource(t *testing.T) {
+	sim := simgo.NewSimulation(simgo.WithSeed(1))
+	tally := sample(NewExponential(sim.Stream("test"), 2))
+	assertf(t, near(tally.Mean(), 2, 0.02), "tally.Mean() == %f", tally.Mean())
+}
+
+func TestUniformSource(t *testing.T) {
+	src := zeroSource{}
+	u := uniform(&src)
+	assertf(t, u == 0.5, "u == %f", u)
+}
+
+type zeroSource struct {
+	calls int
+}
+
+func (src *zeroSource) Float64() float64 {
+	src.calls++
+	if src.calls == 1 {
+		return 0
+	}
+	return 0.5
+}
@@ -0,0 +1,46 @@
+package dist
+
+import "sort"
+
+// Empirical is a continuous distribution derived from observed data. The
+// empirical distribution function of the observations is linearly interpolated
+// between consecutive observations, so random variates lie between the
+// smallest and the largest observation.
+type Empirical struct {
+	// src is the source of random numbers.
+	src Source
+
+	// observations holds the sorted observations.
+	observations []float64
+}
+
+// NewEmpirical creates an empirical distribution from the given observations.
+// The observations are copied. Panics if no observations are given.
+func NewEmpirical(src Source, observations []float64) *Empirical {
+	if len(observations) == 0 {
+		panic("NewEmpirical: observations must not be empty")
+	}
+
+	sorted := append([]float64(nil), observations...)
+	sort.Float64s(sorted)
+
+	return &Empirical{src: src, observations: sorted}
+}
+
+// Sample returns a random variate using the inverse transform method.
+func (d *Empirical) Sample() float64 {
+	if len(d.observations) == 1 {
+		return d.observations[0]
+	}
+
+	pos := d.src.Float64() * float64(len(d.observations)-1)
+	i := int(pos)
+	frac := pos - float64(i)
+
+	// guard against rounding errors
+	if i >= len(d.observations)-1 {
+		return d.observations[len(d.observations)-1]
+	}
+
+	return d.observations[i] + frac*(d.observations[i+1]-d.observations[i])
+}
@@ -0,0 +1,24 @@
+package dist
+
+import "testing"
+
+func TestEmpirical(t *testing.T) {
+	tally := sample(NewEmpirical(newSource(), []float64{4, 0, 2}))
+	assertf(t, near(tally.Mean(), 2, 0.02), "tally.Mean() == %f", tally.Mean())
+	assertf(t, tally.Min() >= 0, "tally.Min() == %f", tally.Min())
+	assertf(t, tally.Max() <= 4, "tally.Max() == %f", tally.Max())
+}
+
+func TestEmpiricalSingle(t *testing.T) {
+	d := NewEmpirical(newSource(), []float64{3})
+	x := d.Sample()
+	assertf(t, x == 3, "x == %f", x)
+}
+
+func TestEmpiricalInvalid(t *testing.T) {
+	defer func() {
+		assertf(t, recover() != nil, "NewEmpirical did not panic")
+	}()
+
+	NewEmpirical(newSource(), nil)
+}
@@ -29,8 +29,15 @@ const (
 // and distinguish between them.
 type Handler func(ev *Event)
 
-// Event is an event in a discrete-event simulation. The event does not contain
-// information about whether it is scheduled to be processed.
+// Event is an event in a discrete-event simulation. A scheduled event is
+// contained in the event queue of its simulation once, at the earliest time it
+// was scheduled for. It can be unscheduled using (*Event).Unschedule or moved
+// using (*Event).Reschedule.
+//
+// An event can carry a value or an error, which are set when the event is
+// triggered using (*Event).Succeed or (*Event).Fail. A failed event is
+// processed like any other event, but processes waiting for it receive the
+// error from (Process).Wait.
 //
 // To create a new event, use (*Simulation).Timeout, (*Simulation).Event,
 // (*Simulation).AnyOf, or (*Simulation).AllOf:
@@ -53,6 +60,23 @@ type Event struct {
 	// handlers holds all abort handlers of the event. These handlers will be
 	// called when the event is aborted.
 	abortHandlers []Handler
+
+	// value holds the value set by (*Event).Succeed.
+	value any
+
+	// err holds the error set by (*Event).Fail.
+	err error
+
+	// index holds the position of the event in the event list, or -1 if the
+	// event is not scheduled. Its meaning depends on the event list.
+	index int
+
+	// time holds the time at which the event is scheduled to be processed.
+	time float64
+
+	// id holds an incremental ID to sort events scheduled at the same time by
+	// insertion order.
+	id uint64
 }
 
 // Trigger schedules the event to be processed immediately. This will call all
@@ -69,6 +93,44 @@ func (ev *Event) Trigger() bool {
 	return true
 }
 
+// Succeed sets the value of the event and schedules the event to be processed
+// immediately. This will call all normal handlers of the event.
+//
+// If the event is not pending, the value is not set and the event will not be
+// scheduled.
+//
+// Returns true if the event has been scheduled or false otherwise.
+func (ev *Event) Succeed(value any) bool {
+	if !ev.Pending() {
+		return false
+	}
+
+	ev.value = value
+	return ev.Trigger()
+}
+
+// Fail sets the error of the event and schedules the event to be processed
+// immediately. This will call all normal handlers of the event. Processes
+// waiting for the event will receive the error.
+//
+// If the event is not pending, the error is not set and the event will not be
+// scheduled.
+//
+// Returns true if the event has been scheduled or false otherwise. Panics if
+// the given error is nil.
+func (ev *Event) Fail(err error) bool {
+	if err == nil {
+		panic("(*Event).Fail: err must not be nil")
+	}
+
+	if !ev.Pending() {
+		return false
+	}
+
+	ev.err = err
+	return ev.Trigger()
+}
+
 // TriggerDelayed schedules the event to be processed after the given delay.
 // This will call all normal handlers of the event.
 //
@@ -79,7 +141,8 @@ func (ev *Event) Trigger() bool {
 //
 // Note that an event can be triggered delayed multiple times, or triggered
 // immediately after it is already triggered delayed. The event will be
-// processed once at the earliest scheduled time.
+// processed once at the earliest scheduled time. To schedule the event later
+// than before, use (*Event).Reschedule.
 func (ev *Event) TriggerDelayed(delay float64) bool {
 	if delay < 0 {
 		panic(fmt.Sprintf("(*Event).TriggerDelayed: delay must not be negative: %f", delay))
@@ -94,7 +157,46 @@ func (ev *Event) TriggerDelayed(delay float64) bool {
 	return true
 }
 
-// Abort aborts the event and calls all abort handlers of the event.
+// Unschedule removes the event from the event queue if it was triggered
+// delayed. The event stays pending and can be triggered again. To cancel a
+// request for a resource or a get or put of a store or container, use their
+// Cancel method instead.
+//
+// If the event is not pending or not scheduled, nothing happens.
+//
+// Returns true if the event has been removed from the event queue or false
+// otherwise.
+func (ev *Event) Unschedule() bool {
+	if !ev.Pending() {
+		return false
+	}
+
+	return ev.sim.unschedule(ev)
+}
+
+// Reschedule schedules the event to be processed after the given delay. If the
+// event was already triggered delayed, it is moved, even if it is scheduled
+// later than before. This is useful for timers which are restarted.
+//
+// If the event is not pending, it will not be scheduled.
+//
+// Returns true if the event has been scheduled or false otherwise. Panics if
+// the given delay is negative.
+func (ev *Event) Reschedule(delay float64) bool {
+	if delay < 0 {
+		panic(fmt.Sprintf("(*Event).Reschedule: delay must not be negative: %f", delay))
+	}
+
+	if !ev.Pending() {
+		return false
+	}
+
+	ev.sim.reschedule(ev, delay)
+	return true
+}
+
+// Abort aborts the event, removes it from the event queue and calls all abort
+// handlers of the event.
 //
 // If the event is not pending, it will not be aborted.
 //
@@ -109,6 +211,7 @@ func (ev *Event) Abort() bool {
 	}
 
 	ev.state = aborted
+	ev.sim.unschedule(ev)
 
 	for _, handler := range ev.abortHandlers {
 		handler(ev)
@@ -146,6 +249,37 @@ func (ev *Event) Aborted() bool {
 	return ev.state == aborted
 }
 
+// Value returns the value set by (*Event).Succeed, or nil if no value was set.
+func (ev *Event) Value() any {
+	return ev.value
+}
+
+// Err returns the error set by (*Event).Fail, or nil if the event did not fail.
+func (ev *Event) Err() error {
+	return ev.err
+}
+
+// String returns a description of the event with its state, its scheduled time
+// if it is scheduled, and its value or error if it is processed.
+func (ev *Event) String() string {
+	switch {
+	case ev.Processed() && ev.err != nil:
+		return fmt.Sprintf("event (processed, error: %v)", ev.err)
+	case ev.Processed() && ev.value != nil:
+		return fmt.Sprintf("event (processed, value: %v)", ev.value)
+	case ev.Processed():
+		return "event (processed)"
+	case ev.Aborted():
+		return "event (aborted)"
+	case ev.index >= 0 && ev.state == triggered:
+		return fmt.Sprintf("event (triggered at %f)", ev.time)
+	case ev.index >= 0:
+		return fmt.Sprintf("event (pending, scheduled at %f)", ev.time)
+	default:
+		return "event (pending)"
+	}
+}
+
 // AddHandler adds the given handler as a normal handler to the event. The
 // handler will be called when the event is processed.
 //
@@ -1,6 +1,7 @@
 package simgo_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/fschuetz04/simgo"
@@ -68,3 +69,141 @@ func TestAbortTriggered(t *testing.T) {
 	assertf(t, ev.Abort() == false, "ev.Abort() == true")
 	assertf(t, ev.Aborted() == false, "ev.Aborted() == true")
 }
+
+func TestSucceed(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev := sim.Event()
+	assertf(t, ev.Succeed(5) == true, "ev.Succeed(5) == false")
+	assertf(t, ev.Succeed(6) == false, "ev.Succeed(6) == true")
+	assertf(t, ev.Triggered() == true, "ev.Triggered() == false")
+	assertf(t, ev.Value() == 5, "ev.Value() == %v", ev.Value())
+	assertf(t, ev.Err() == nil, "ev.Err() == %v", ev.Err())
+}
+
+func TestFail(t *testing.T) {
+	sim := simgo.NewSimulation()
+	errFailed := errors.New("failed")
+
+	ev := sim.Event()
+	assertf(t, ev.Fail(errFailed) == true, "ev.Fail(errFailed) == false")
+	assertf(t, ev.Succeed(5) == false, "ev.Succeed(5) == true")
+	assertf(t, ev.Triggered() == true, "ev.Triggered() == false")
+	assertf(t, ev.Value() == nil, "ev.Value() == %v", ev.Value())
+	assertf(t, ev.Err() == errFailed, "ev.Err() == %v", ev.Err())
+
+	sim.Run()
+	assertf(t, ev.Processed() == true, "ev.Processed() == false")
+}
+
+func TestFailNil(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := simgo.NewSimulation()
+
+	ev := sim.Event()
+	ev.Fail(nil)
+}
+
+func TestEventUnschedule(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev := sim.Timeout(5)
+	assertf(t, ev.Unschedule(), "ev.Unschedule() == false")
+	assertf(t, !ev.Unschedule(), "ev.Unschedule() == true")
+	assertf(t, ev.Pending(), "ev.Pending() == false")
+
+	sim.Run()
+	assertf(t, sim.Now() == 0, "sim.Now() == %f", sim.Now())
+	assertf(t, ev.Pending(), "ev.Pending() == false")
+
+	// a cancelled event can be triggered again
+	ev.TriggerDelayed(2)
+	sim.Run()
+	assertf(t, sim.Now() == 2, "sim.Now() == %f", sim.Now())
+	assertf(t, ev.Processed(), "ev.Processed() == false")
+	assertf(t, !ev.Unschedule(), "ev.Unschedule() == true")
+}
+
+func TestEventReschedule(t *testing.T) {
+	sim := simgo.NewSimulation()
+	var processedAt []float64
+
+	watchdog := sim.Timeout(3)
+	watchdog.AddHandler(func(*simgo.Event) {
+		processedAt = append(processedAt, sim.Now())
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		for i := 0; i < 3; i++ {
+			proc.Wait(proc.Timeout(2))
+			watchdog.Reschedule(3)
+		}
+	})
+
+	sim.Run()
+	assertf(t, len(processedAt) == 1, "len(processedAt) == %d", len(processedAt))
+	assertf(t, processedAt[0] == 9, "processedAt[0] == %f", processedAt[0])
+	assertf(t, sim.Now() == 9, "sim.Now() == %f", sim.Now())
+	assertf(t, !watchdog.Reschedule(1), "watchdog.Reschedule(1) == true")
+}
+
+func TestEventRescheduleEarlier(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev := sim.Timeout(10)
+	ev.TriggerDelayed(20)
+	ev.Reschedule(4)
+
+	sim.Run()
+	assertf(t, ev.Processed(), "ev.Processed() == false")
+	assertf(t, sim.Now() == 4, "sim.Now() == %f", sim.Now())
+}
+
+func TestEventAbortUnschedules(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev := sim.Timeout(10)
+	ev.Abort()
+
+	sim.Run()
+	assertf(t, sim.Now() == 0, "sim.Now() == %f", sim.Now())
+}
+
+func TestEventRescheduleNegative(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := simgo.NewSimulation()
+	sim.Event().Reschedule(-1)
+}
+
+func TestEventString(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev := sim.Event()
+	assertf(t, ev.String() == "event (pending)", "ev.String() == %s", ev.String())
+
+	ev.TriggerDelayed(5)
+	assertf(t, ev.String() == "event (pending, scheduled at 5.000000)", "ev.String() == %s", ev.String())
+
+	ev.Succeed(42)
+	assertf(t, ev.String() == "event (triggered at 0.000000)", "ev.String() == %s", ev.String())
+
+	sim.Run()
+	assertf(t, ev.String() == "event (processed, value: 42)", "ev.String() == %s", ev.String())
+
+	failed := sim.Event()
+	failed.Fail(errors.New("failure"))
+	sim.Run()
+	assertf(t, failed.String() == "event (processed, error: failure)", "failed.String() == %s", failed.String())
+
+	aborted := sim.Event()
+	aborted.Abort()
+	assertf(t, aborted.String() == "event (aborted)", "aborted.String() == %s", aborted.String())
+}
@@ -0,0 +1,146 @@
+package simgo
+
+import "container/heap"
+
+// EventList selects the data structure holding the scheduled events of a
+// simulation. All event lists process events in the same order, but perform
+// differently depending on the number of scheduled events and the
+// distribution of their times.
+type EventList int
+
+const (
+	// BinaryHeap holds the scheduled events in a binary heap with O(log n)
+	// operations. This is the default.
+	BinaryHeap EventList = iota
+
+	// CalendarQueue holds the scheduled events in a calendar queue with O(1)
+	// amortized operations, which is faster for many scheduled events.
+	CalendarQueue
+)
+
+// WithEventList sets the data structure holding the scheduled events. Panics
+// if the given event list is unknown.
+func WithEventList(list EventList) Option {
+	switch list {
+	case BinaryHeap, CalendarQueue:
+	default:
+		panic("WithEventList: unknown event list")
+	}
+
+	return func(sim *Simulation) {
+		sim.events = newEventList(list)
+	}
+}
+
+// newEventList creates an empty event list of the given kind.
+func newEventList(list EventList) eventList {
+	if list == CalendarQueue {
+		return newCalendarQueue()
+	}
+
+	return &eventHeap{}
+}
+
+// eventList holds all scheduled events for a discrete-event simulation,
+// ordered by their time and ID. Each event is contained at most once. An event
+// list sets the index of a contained event to a non-negative value and resets
+// it to -1 when the event is removed.
+type eventList interface {
+	// len returns the number of scheduled events.
+	len() int
+
+	// insert adds the given event, whose time and ID are set.
+	insert(ev *Event)
+
+	// min returns the next event without removing it, or nil if no event is
+	// scheduled.
+	min() *Event
+
+	// removeMin removes and returns the next event, or nil if no event is
+	// scheduled.
+	removeMin() *Event
+
+	// remove removes the given event, which must be contained.
+	remove(ev *Event)
+}
+
+// before returns whether event a is scheduled before event b.
+func before(a *Event, b *Event) bool {
+	if a.time != b.time {
+		return a.time < b.time
+	}
+
+	return a.id < b.id
+}
+
+// eventHeap is an event list implemented as an indexed binary heap. The index
+// of each event is its position in the heap.
+type eventHeap []*Event
+
+// len returns the number of scheduled events.
+func (eh *eventHeap) len() int {
+	return len(*eh)
+}
+
+// insert adds the given event.
+func (eh *eventHeap) insert(ev *Event) {
+	heap.Push(eh, ev)
+}
+
+// min returns the next event without removing it.
+func (eh *eventHeap) min() *Event {
+	if len(*eh) == 0 {
+		return nil
+	}
+
+	return (*eh)[0]
+}
+
+// removeMin removes and returns the next event.
+func (eh *eventHeap) removeMin() *Event {
+	if len(*eh) == 0 {
+		return nil
+	}
+
+	return heap.Pop(eh).(*Event)
+}
+
+// remove removes the given event.
+func (eh *eventHeap) remove(ev *Event) {
+	heap.Remove(eh, ev.index)
+}
+
+// Len returns the number of scheduled events.
+func (eh eventHeap) Len() int {
+	return len(eh)
+}
+
+// Less returns whether the event at position i is scheduled before the event
+// at position j.
+func (eh eventHeap) Less(i, j int) bool {
+	return before(eh[i], eh[j])
+}
+
+// Swap swaps the scheduled events at position i and j.
+func (eh eventHeap) Swap(i, j int) {
+	eh[i], eh[j] = eh[j], eh[i]
+	eh[i].index = i
+	eh[j].index = j
+}
+
+// Push appends the given scheduled event at the back.
+func (eh *eventHeap) Push(item any) {
+	ev := item.(*Event)
+	ev.index = len(*eh)
+	*eh = append(*eh, ev)
+}
+
+// Pop removes and returns the scheduled event at the back.
+func (eh *eventHeap) Pop() any {
+	n := len(*eh)
+	ev := (*eh)[n-1]
+	(*eh)[n-1] = nil
+	*eh = (*eh)[:n-1]
+	ev.index = -1
+	return ev
+}
@@ -0,0 +1,153 @@
+package simgo
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+var eventLists = []struct {
+	name string
+	list EventList
+}{
+	{"BinaryHeap", BinaryHeap},
+	{"CalendarQueue", CalendarQueue},
+}
+
+func TestEventListSingleEntry(t *testing.T) {
+	for _, el := range eventLists {
+		sim := NewSimulation(WithEventList(el.list))
+
+		ev := sim.Timeout(10)
+		ev.TriggerDelayed(5)
+		ev.TriggerDelayed(8)
+		assertf(t, sim.events.len() == 1, "%s: sim.events.len() == %d", el.name, sim.events.len())
+		assertf(t, ev.time == 5, "%s: ev.time == %f", el.name, ev.time)
+
+		ev.Trigger()
+		assertf(t, sim.events.len() == 1, "%s: sim.events.len() == %d", el.name, sim.events.len())
+		assertf(t, ev.time == 0, "%s: ev.time == %f", el.name, ev.time)
+	}
+}
+
+func TestEventListRemove(t *testing.T) {
+	for _, el := range eventLists {
+		sim := NewSimulation(WithEventList(el.list))
+
+		evs := make([]*Event, 10)
+		for i := range evs {
+			evs[i] = sim.Timeout(float64(i))
+		}
+
+		evs[3].Unschedule()
+		evs[7].Abort()
+		evs[5].Reschedule(20)
+		assertf(t, sim.events.len() == 8, "%s: sim.events.len() == %d", el.name, sim.events.len())
+
+		var order []float64
+		for sim.Step() {
+			order = append(order, sim.Now())
+		}
+
+		expected := []float64{0, 1, 2, 4, 6, 8, 9, 20}
+		assertf(t, fmt.Sprint(order) == fmt.Sprint(expected), "%s: order == %v", el.name, order)
+		assertf(t, evs[3].index == -1, "%s: evs[3].index == %d", el.name, evs[3].index)
+	}
+}
+
+func TestEventHeapIndex(t *testing.T) {
+	sim := NewSimulation()
+
+	for i := 0; i < 10; i++ {
+		sim.Timeout(float64(10 - i))
+	}
+
+	eh := *sim.events.(*eventHeap)
+	for i, ev := range eh {
+		assertf(t, ev.index == i, "ev.index == %d, i == %d", ev.index, i)
+	}
+}
+
+func TestCalendarQueueOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sims := []*Simulation{
+		NewSimulation(WithEventList(BinaryHeap)),
+		NewSimulation(WithEventList(CalendarQueue)),
+	}
+	var orders [2][]uint64
+
+	for i, sim := range sims {
+		r.Seed(1)
+		var scheduled []*Event
+
+		// grow the event list to force resizes, process events while
+		// scheduling, cancelling and rescheduling others, then drain it
+		for step := 0; step < 20000; step++ {
+			switch op := r.Intn(10); {
+			case op < 5 || step < 2000:
+				delay := r.ExpFloat64() * 10
+				if r.Intn(5) == 0 {
+					// simultaneous events
+					delay = float64(r.Intn(3))
+				}
+				scheduled = append(scheduled, sim.Timeout(delay))
+
+			case op < 7 && len(scheduled) > 0:
+				ev := scheduled[r.Intn(len(scheduled))]
+				ev.Unschedule()
+
+			case op < 8 && len(scheduled) > 0:
+				ev := scheduled[r.Intn(len(scheduled))]
+				ev.Reschedule(r.Float64() * 50)
+
+			default:
+				ev := sim.events.min()
+				if sim.Step() {
+					orders[i] = append(orders[i], ev.id)
+				}
+			}
+		}
+
+		for ev := sim.events.min(); ev != nil; ev = sim.events.min() {
+			sim.Step()
+			orders[i] = append(orders[i], ev.id)
+		}
+	}
+
+	assertf(t, len(orders[0]) > 5000, "len(orders[0]) == %d", len(orders[0]))
+	assertf(t, len(orders[0]) == len(orders[1]), "len(orders[0]) == %d, len(orders[1]) == %d", len(orders[0]), len(orders[1]))
+	for i := range orders[0] {
+		if orders[0][i] != orders[1][i] {
+			t.Fatalf("orders differ at %d: %d != %d", i, orders[0][i], orders[1][i])
+		}
+	}
+}
+
+// BenchmarkEventList measures the hold model: the next event is removed and a
+// new event is inserted with an exponentially distributed delay, so the number
+// of scheduled events stays constant.
+func BenchmarkEventList(b *testing.B) {
+	for _, el := range eventLists {
+		for _, n := range []int{100, 10000, 1000000} {
+			b.Run(fmt.Sprintf("%s/%d", el.name, n), func(b *testing.B) {
+				r := rand.New(rand.NewSource(1))
+				list := newEventList(el.list)
+				id := uint64(0)
+
+				for i := 0; i < n; i++ {
+					list.insert(&Event{time: r.ExpFloat64(), id: id})
+					id++
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					ev := list.removeMin()
+					ev.time += r.ExpFloat64()
+					ev.id = id
+					id++
+					list.insert(ev)
+				}
+			})
+		}
+	}
+}
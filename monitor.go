@@ -0,0 +1,68 @@
+package simgo
+
+import "github.com/fschuetz04/simgo/stats"
+
+// QueueStats holds statistics about a queue of pending requests, gets or puts
+// since the last reset.
+type QueueStats struct {
+	// MeanLength is the time-weighted average length of the queue.
+	MeanLength float64
+
+	// MaxLength is the maximum length of the queue.
+	MaxLength int
+
+	// Served is the number of served requests, including requests which were
+	// served immediately.
+	Served int
+
+	// MeanWait is the average time served requests waited in the queue.
+	MeanWait float64
+
+	// MaxWait is the maximum time a served request waited in the queue.
+	MaxWait float64
+}
+
+// queueMonitor accumulates statistics about a queue.
+type queueMonitor struct {
+	// sim is the reference to the simulation.
+	sim *Simulation
+
+	// length accumulates the length of the queue.
+	length *stats.TimeWeighted
+
+	// waits accumulates the waiting times of served requests.
+	waits stats.Tally
+}
+
+// newQueueMonitor creates a queue monitor for the given simulation.
+func newQueueMonitor(sim *Simulation) queueMonitor {
+	return queueMonitor{sim: sim, length: stats.NewTimeWeighted(sim, 0)}
+}
+
+// serve records a served request which was queued at the given simulation
+// time.
+func (qm *queueMonitor) serve(queued float64) {
+	qm.waits.Add(qm.sim.Now() - queued)
+}
+
+// stats returns the statistics about the queue.
+func (qm *queueMonitor) stats() QueueStats {
+	stats := QueueStats{
+		MeanLength: qm.length.Mean(),
+		MaxLength:  int(qm.length.Max()),
+		Served:     qm.waits.Count(),
+	}
+
+	if qm.waits.Count() > 0 {
+		stats.MeanWait = qm.waits.Mean()
+		stats.MaxWait = qm.waits.Max()
+	}
+
+	return stats
+}
+
+// reset restarts the accumulation at the current simulation time.
+func (qm *queueMonitor) reset() {
+	qm.length.Reset()
+	qm.waits.Reset()
+}
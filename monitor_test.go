@@ -0,0 +1,80 @@
+package simgo
+
+import "testing"
+
+func TestResourceStats(t *testing.T) {
+	sim := NewSimulation()
+	res := NewResource(sim, 1)
+
+	for i := 0; i < 2; i++ {
+		delay := float64(4 - 2*i)
+		sim.Process(func(proc Process) {
+			proc.Use(res, func() {
+				proc.Wait(proc.Timeout(delay))
+			})
+		})
+	}
+
+	sim.RunUntil(10)
+
+	stats := res.Stats()
+	assertf(t, stats.MeanBusy == 0.6, "stats.MeanBusy == %f", stats.MeanBusy)
+	assertf(t, stats.MaxBusy == 1, "stats.MaxBusy == %d", stats.MaxBusy)
+	assertf(t, stats.Utilization == 0.6, "stats.Utilization == %f", stats.Utilization)
+	assertf(t, stats.Queue.MeanLength == 0.4, "stats.Queue.MeanLength == %f", stats.Queue.MeanLength)
+	assertf(t, stats.Queue.MaxLength == 1, "stats.Queue.MaxLength == %d", stats.Queue.MaxLength)
+	assertf(t, stats.Queue.Served == 2, "stats.Queue.Served == %d", stats.Queue.Served)
+	assertf(t, stats.Queue.MeanWait == 2, "stats.Queue.MeanWait == %f", stats.Queue.MeanWait)
+	assertf(t, stats.Queue.MaxWait == 4, "stats.Queue.MaxWait == %f", stats.Queue.MaxWait)
+
+	res.ResetStats()
+
+	stats = res.Stats()
+	assertf(t, stats.MeanBusy == 0, "stats.MeanBusy == %f", stats.MeanBusy)
+	assertf(t, stats.MaxBusy == 0, "stats.MaxBusy == %d", stats.MaxBusy)
+	assertf(t, stats.Queue.Served == 0, "stats.Queue.Served == %d", stats.Queue.Served)
+}
+
+func TestStoreStats(t *testing.T) {
+	sim := NewSimulation()
+	store := NewStore[int](sim)
+
+	sim.Process(func(proc Process) {
+		get := store.Get()
+		proc.Wait(proc.Timeout(2))
+		store.Put(1)
+		store.Put(2)
+		proc.Wait(get)
+		proc.Wait(proc.Timeout(4))
+		store.Get()
+	})
+
+	sim.RunUntil(10)
+
+	stats := store.Stats()
+	assertf(t, stats.MeanItems == 0.4, "stats.MeanItems == %f", stats.MeanItems)
+	assertf(t, stats.MaxItems == 2, "stats.MaxItems == %d", stats.MaxItems)
+	assertf(t, stats.Gets.Served == 2, "stats.Gets.Served == %d", stats.Gets.Served)
+	assertf(t, stats.Gets.MeanWait == 1, "stats.Gets.MeanWait == %f", stats.Gets.MeanWait)
+	assertf(t, stats.Gets.MeanLength == 0.2, "stats.Gets.MeanLength == %f", stats.Gets.MeanLength)
+	assertf(t, stats.Puts.Served == 2, "stats.Puts.Served == %d", stats.Puts.Served)
+	assertf(t, stats.Puts.MaxWait == 0, "stats.Puts.MaxWait == %f", stats.Puts.MaxWait)
+}
+
+func TestContainerStats(t *testing.T) {
+	sim := NewSimulation()
+	con := NewContainerWithLevel(sim, 10, 10)
+
+	sim.Process(func(proc Process) {
+		proc.Wait(proc.Timeout(5))
+		con.Get(5)
+	})
+
+	sim.RunUntil(10)
+
+	stats := con.Stats()
+	assertf(t, stats.MeanLevel == 7.5, "stats.MeanLevel == %f", stats.MeanLevel)
+	assertf(t, stats.MaxLevel == 10, "stats.MaxLevel == %f", stats.MaxLevel)
+	assertf(t, stats.Gets.Served == 1, "stats.Gets.Served == %d", stats.Gets.Served)
+	assertf(t, stats.Puts.Served == 0, "stats.Puts.Served == %d", stats.Puts.Served)
+}
@@ -1,21 +1,23 @@
 package simgo
 
-import "runtime"
+import (
+	"fmt"
+)
 
 // Process is a process in a discrete-event simulation.
 //
 // A process can wait for events and other processes, create new events and
 // start new processes.
 //
-// To start a process, use (*Simulation).Process or
-// (*Simulation).ProcessReflect:
+// To start a process, use (*Simulation).Process or one of Process1 to
+// Process4 to pass additional arguments:
 //
-//	func myProcess (proc simgo.Process) {
-//	    fmt.Println("Start")
+//	func myProcess (proc simgo.Process, name string) {
+//	    fmt.Println("Start", name)
 //	    proc.Wait(proc.Timeout(5))
-//	    fmt.Println("End")
+//	    fmt.Println("End", name)
 //	}
-//	sim.Process(myProcess)
+//	simgo.Process1(sim, myProcess, "A")
 //
 // Process encapsulates *Simulation, so all its methods can be used.
 type Process struct {
@@ -27,60 +29,367 @@ type Process struct {
 	// aborted.
 	ev *Event
 
-	// sync is used to yield to the process / simulation and wait for the
-	// process / simulation.
-	sync chan bool
+	// data holds the state shared by all copies of the process.
+	data *processData
+}
+
+// processData holds the mutable state of a process. Since Process is passed by
+// value, this state is stored behind a pointer.
+type processData struct {
+	// id is the ID of the process, which is unique in its simulation.
+	id uint64
+
+	// name is the name of the process, or empty if the process has no name.
+	name string
+
+	// state is the state of the process.
+	state ProcessState
+
+	// started is the simulation time at which the process was started.
+	started float64
+
+	// awaiting holds the awaitable the process is waiting for, or nil if the
+	// process is not waiting.
+	awaiting Awaitable
+
+	// since holds the simulation time at which the process started waiting.
+	since float64
+
+	// interrupts holds the causes of interrupts which have not yet been
+	// delivered to the process.
+	interrupts []any
+
+	// wakeup resumes the process while it is waiting for an event. It is nil
+	// if the process is not waiting.
+	wakeup func()
+
+	// requests holds the pending and granted requests for resources made by
+	// the process, which are cancelled or released if the process is aborted.
+	requests []*Request
+
+	// next resumes the process coroutine until it suspends itself or
+	// finishes.
+	next func() (struct{}, bool)
+
+	// stop stops the process coroutine.
+	stop func()
+
+	// suspend suspends the process coroutine and yields to the simulation.
+	// Returns false if the coroutine is stopped.
+	suspend func(struct{}) bool
+
+	// processed is passed to the process when it is resumed. It is false if
+	// the awaited event was aborted.
+	processed bool
+
+	// value holds the value of the awaited event once it is processed.
+	value any
+
+	// err holds the error of the awaited event once it is processed.
+	err error
+}
+
+// ProcessState is the state of a process.
+type ProcessState int
+
+const (
+	// ProcessCreated means that the process was started, but its runner has
+	// not been executed yet.
+	ProcessCreated ProcessState = iota
+
+	// ProcessRunning means that the runner of the process is executed.
+	ProcessRunning
+
+	// ProcessWaiting means that the process is waiting for an awaitable.
+	ProcessWaiting
+
+	// ProcessFinished means that the runner of the process returned.
+	ProcessFinished
+
+	// ProcessAborted means that the process was aborted because an awaitable
+	// it was waiting for was aborted, that it was stopped by
+	// (*Simulation).Shutdown, or that its runner panicked.
+	ProcessAborted
+)
+
+// String returns the name of the process state.
+func (state ProcessState) String() string {
+	switch state {
+	case ProcessCreated:
+		return "created"
+	case ProcessRunning:
+		return "running"
+	case ProcessWaiting:
+		return "waiting"
+	case ProcessFinished:
+		return "finished"
+	case ProcessAborted:
+		return "aborted"
+	default:
+		return fmt.Sprintf("ProcessState(%d)", int(state))
+	}
+}
+
+// processExit is panicked with to unwind the stack of a process coroutine when
+// the process is aborted or the simulation is shut down. It is recovered when
+// the runner returns.
+type processExit struct{}
+
+// Interrupt is the error returned from (Process).Wait when the waiting process
+// is interrupted.
+type Interrupt struct {
+	// Cause is the cause given to (Process).Interrupt.
+	Cause any
+}
+
+// Error returns a description of the interrupt.
+func (interrupt *Interrupt) Error() string {
+	return fmt.Sprintf("process interrupted: %v", interrupt.Cause)
 }
 
 // Wait yields from the process to the simulation and waits until the given
 // awaitable is processed.
 //
 // If the awaitable is already processed, the process is not paused. If the
-// awaitable is aborted, the process is aborted too.
-func (proc Process) Wait(ev Awaitable) {
+// awaitable is aborted, the process is aborted too. In this case, all pending
+// requests for resources made by the process are cancelled and all granted
+// ones are released.
+//
+// An aborted process is stopped by unwinding its stack, so deferred functions
+// are executed. If the runner recovers from this, it is stopped again as soon
+// as it waits the next time, and the underlying event is not triggered when
+// the runner returns.
+//
+// Returns an *Interrupt if the process is interrupted while waiting, the error
+// of the awaitable if it failed, or nil otherwise. After an interrupt, the
+// awaitable is no longer waited for, but it can be waited for again.
+func (proc Process) Wait(ev Awaitable) error {
+	_, err := proc.wait(ev)
+	return err
+}
+
+// ProcessPanic is panicked with from (*Simulation).Step and returned from
+// (*Simulation).RunContext if the runner of a process panics. It annotates the
+// original panic value with the process and the simulation time.
+type ProcessPanic struct {
+	// Process is the process whose runner panicked.
+	Process Process
+
+	// Now is the simulation time at which the runner panicked.
+	Now float64
+
+	// Value is the original panic value.
+	Value any
+
+	// Stack is the stack trace of the process at the time of the panic.
+	Stack []byte
+}
+
+// Error returns a description of the panic including the stack trace of the
+// process.
+func (p *ProcessPanic) Error() string {
+	return fmt.Sprintf("panic in %s at %f: %v\n\n%s", p.Process, p.Now, p.Value, p.Stack)
+}
+
+// Unwrap returns the original panic value if it is an error, or nil otherwise.
+func (p *ProcessPanic) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// WaitValue waits until the given awaitable is processed like (Process).Wait
+// and returns the value of the awaitable.
+//
+// Returns nil and an error if (Process).Wait returns an error.
+func (proc Process) WaitValue(ev Awaitable) (any, error) {
+	value, err := proc.wait(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// wait waits until the given awaitable is processed as described for
+// (Process).Wait and returns its value and error.
+func (proc Process) wait(ev Awaitable) (any, error) {
+	if proc.data.state == ProcessAborted {
+		// the runner recovered after the process was aborted or stopped, so
+		// stop it again
+		panic(processExit{})
+	}
+
+	if proc.stopped {
+		// simulation shut down, possibly by this process
+		proc.exit()
+	}
+
+	if err := proc.interrupted(); err != nil {
+		// interrupt is pending, do not wait
+		return nil, err
+	}
+
 	if ev.Processed() {
 		// event was already processed, do not wait
-		return
+		return ev.Value(), ev.Err()
 	}
 
 	if ev.Aborted() {
 		// event aborted, abort process
-		proc.ev.Abort()
-		runtime.Goexit()
+		proc.abort()
 	}
 
-	// handler called when the event is processed
-	ev.AddHandler(func(*Event) {
-		// yield to process
-		proc.sync <- true
+	// the handlers registered below might be called after the process was
+	// interrupted, in which case they must not resume the process
+	waiting := true
+	resume := func(processed bool) {
+		if !waiting {
+			return
+		}
+		waiting = false
+		proc.data.wakeup = nil
 
-		// wait for process
-		<-proc.sync
+		proc.yield(processed)
+	}
+
+	// handler called when the event is processed, which copies the value and
+	// error so the process does not need the event once it is resumed
+	ev.AddHandler(func(ev *Event) {
+		if waiting {
+			proc.data.value, proc.data.err = ev.Value(), ev.Err()
+		}
+		resume(true)
 	})
 
 	// handler called when the event is aborted
-	ev.AddAbortHandler(func(*Event) {
-		// abort process
-		proc.sync <- false
+	ev.AddAbortHandler(func(*Event) { resume(false) })
+
+	// called when the process is interrupted
+	proc.data.wakeup = func() { resume(true) }
 
-		// wait for process
-		<-proc.sync
+	proc.data.awaiting = ev
+	proc.data.since = proc.Now()
+	proc.data.state = ProcessWaiting
+
+	// yield to simulation and wait until resumed
+	resumed := proc.data.suspend(struct{}{})
+	proc.data.awaiting = nil
+	proc.data.state = ProcessRunning
+
+	if !resumed || proc.stopped {
+		// simulation shut down, stop process
+		proc.exit()
+	}
+
+	if !proc.data.processed {
+		// event aborted, abort process
+		proc.abort()
+	}
+
+	if err := proc.interrupted(); err != nil {
+		return nil, err
+	}
+
+	value, err := proc.data.value, proc.data.err
+	proc.data.value, proc.data.err = nil, nil
+	return value, err
+}
+
+// Interrupt interrupts the process with the given cause.
+//
+// Creates and triggers an event. As soon as this event is processed, the
+// process stops waiting and (Process).Wait returns an *Interrupt holding the
+// given cause. If the process is not waiting at that time because it has not
+// started yet, the interrupt is delivered by its next call to (Process).Wait.
+// If the process has finished by then, the interrupt is discarded.
+//
+// Panics if the process has already finished or was aborted.
+func (proc Process) Interrupt(cause any) {
+	if !proc.ev.Pending() {
+		panic("(Process).Interrupt: process has already finished")
+	}
+
+	ev := proc.Timeout(0)
+	ev.AddHandler(func(*Event) {
+		if !proc.ev.Pending() {
+			// process finished in the meantime
+			return
+		}
+
+		proc.data.interrupts = append(proc.data.interrupts, cause)
+
+		if proc.data.wakeup != nil {
+			proc.data.wakeup()
+		}
 	})
+}
 
-	// yield to simulation
-	proc.sync <- true
+// Use requests an instance of the given resource, waits until the request is
+// granted, calls f and releases the instance afterwards. The instance is also
+// released if the process is aborted while f is executed.
+//
+// Returns the error from (Process).Wait if waiting for the request fails, in
+// which case the request is cancelled and f is not called. Returns nil
+// otherwise.
+func (proc Process) Use(res *Resource, f func()) error {
+	req := res.Request()
 
-	select {
-	case processed := <-proc.sync: // wait for simulation
-		if !processed {
-			// event aborted, abort process
-			proc.ev.Abort()
-			runtime.Goexit()
+	if err := proc.Wait(req); err != nil {
+		if req.Triggered() {
+			req.Release()
+		} else {
+			req.Cancel()
 		}
+		return err
+	}
+
+	defer func() {
+		// the request might already be released if the process was aborted
+		if !req.released {
+			req.Release()
+		}
+	}()
+
+	f()
+
+	return nil
+}
+
+// ID returns the ID of the process, which is unique in its simulation.
+// Processes are numbered in the order they were started.
+func (proc Process) ID() uint64 {
+	return proc.data.id
+}
 
-	case <-proc.shutdown: // wait for simulation shutdown
-		runtime.Goexit()
+// Name returns the name of the process, or an empty string if the process has
+// no name.
+func (proc Process) Name() string {
+	return proc.data.name
+}
+
+// SetName sets the name of the process, which is shown in descriptions of the
+// process. Names do not need to be unique.
+func (proc Process) SetName(name string) {
+	proc.data.name = name
+}
+
+// State returns the state of the process.
+func (proc Process) State() ProcessState {
+	return proc.data.state
+}
+
+// Started returns the simulation time at which the process was started.
+func (proc Process) Started() float64 {
+	return proc.data.started
+}
+
+// String returns a description of the process with its ID, name and state.
+func (proc Process) String() string {
+	if proc.data.name == "" {
+		return fmt.Sprintf("process %d (%s)", proc.data.id, proc.data.state)
 	}
+
+	return fmt.Sprintf("process %d %q (%s)", proc.data.id, proc.data.name, proc.data.state)
 }
 
 // Pending returns whether the underlying event is pending.
@@ -103,6 +412,16 @@ func (proc Process) Aborted() bool {
 	return proc.ev.Aborted()
 }
 
+// Value returns the value of the underlying event.
+func (proc Process) Value() any {
+	return proc.ev.Value()
+}
+
+// Err returns the error of the underlying event.
+func (proc Process) Err() error {
+	return proc.ev.Err()
+}
+
 // AddHandler adds the given handler to the underlying event.
 func (proc Process) AddHandler(handler Handler) {
 	proc.ev.AddHandler(handler)
@@ -112,3 +431,63 @@ func (proc Process) AddHandler(handler Handler) {
 func (proc Process) AddAbortHandler(handler Handler) {
 	proc.ev.AddAbortHandler(handler)
 }
+
+// interrupted removes and returns the oldest pending interrupt of the process.
+// Returns nil if no interrupt is pending.
+func (proc Process) interrupted() error {
+	if len(proc.data.interrupts) == 0 {
+		return nil
+	}
+
+	cause := proc.data.interrupts[0]
+	proc.data.interrupts = proc.data.interrupts[1:]
+
+	return &Interrupt{Cause: cause}
+}
+
+// yield yields to the process and waits until the process yields back. The
+// process is the active process of the simulation in the meantime.
+func (proc Process) yield(processed bool) {
+	previous := proc.active
+	proc.active = &proc
+
+	// restore the active process even if the process panicked
+	defer func() {
+		proc.active = previous
+	}()
+
+	proc.data.processed = processed
+	proc.data.next()
+}
+
+// abort releases all granted requests and cancels all pending requests of the
+// process, aborts the underlying event and stops the process coroutine.
+func (proc Process) abort() {
+	proc.data.state = ProcessAborted
+	proc.releaseRequests()
+	proc.ev.Abort()
+	panic(processExit{})
+}
+
+// exit releases all granted requests and cancels all pending requests of the
+// process and stops the process coroutine without aborting the underlying
+// event. This is used when the simulation is shut down.
+func (proc Process) exit() {
+	proc.data.state = ProcessAborted
+	proc.releaseRequests()
+	panic(processExit{})
+}
+
+// releaseRequests releases all granted requests and cancels all pending
+// requests of the process.
+func (proc Process) releaseRequests() {
+	// releasing and cancelling requests modifies the list of requests
+	reqs := append([]*Request(nil), proc.data.requests...)
+	for _, req := range reqs {
+		if req.Triggered() {
+			req.Release()
+		} else {
+			req.Cancel()
+		}
+	}
+}
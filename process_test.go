@@ -1,6 +1,9 @@
 package simgo_test
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/fschuetz04/simgo"
@@ -58,4 +61,393 @@ func TestWaitForAborted(t *testing.T) {
 
 	sim.Run()
 	assertf(t, finished == true, "finished == false")
-}
\ No newline at end of file
+}
+
+func TestInterrupt(t *testing.T) {
+	sim := simgo.NewSimulation()
+	finished := false
+
+	victim := sim.Process(func(proc simgo.Process) {
+		err := proc.Wait(proc.Timeout(10))
+		assertf(t, proc.Now() == 3, "proc.Now() == %f", proc.Now())
+
+		interrupt, ok := err.(*simgo.Interrupt)
+		assertf(t, ok, "err == %v", err)
+		if ok {
+			assertf(t, interrupt.Cause == "breakdown", "interrupt.Cause == %v", interrupt.Cause)
+		}
+
+		err = proc.Wait(proc.Timeout(2))
+		assertf(t, err == nil, "err == %v", err)
+		assertf(t, proc.Now() == 5, "proc.Now() == %f", proc.Now())
+		finished = true
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(3))
+		victim.Interrupt("breakdown")
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestInterruptBeforeStart(t *testing.T) {
+	sim := simgo.NewSimulation()
+	finished := false
+
+	victim := sim.Process(func(proc simgo.Process) {
+		err := proc.Wait(proc.Timeout(10))
+		assertf(t, proc.Now() == 0, "proc.Now() == %f", proc.Now())
+		_, ok := err.(*simgo.Interrupt)
+		assertf(t, ok, "err == %v", err)
+		finished = true
+	})
+
+	victim.Interrupt(nil)
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestInterruptFinished(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := simgo.NewSimulation()
+
+	proc := sim.Process(func(proc simgo.Process) {})
+
+	sim.Run()
+	proc.Interrupt(nil)
+}
+
+func TestWaitValue(t *testing.T) {
+	sim := simgo.NewSimulation()
+	finished := false
+
+	sim.Process(func(proc simgo.Process) {
+		ev := proc.Event()
+		ev.Succeed("value")
+		value, err := proc.WaitValue(ev)
+		assertf(t, value == "value", "value == %v", value)
+		assertf(t, err == nil, "err == %v", err)
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestWaitFailed(t *testing.T) {
+	sim := simgo.NewSimulation()
+	errFailed := errors.New("failed")
+	finished := false
+
+	sim.Process(func(proc simgo.Process) {
+		ev := proc.Event()
+		ev.Fail(errFailed)
+		err := proc.Wait(ev)
+		assertf(t, err == errFailed, "err == %v", err)
+		err = proc.Wait(ev)
+		assertf(t, err == errFailed, "err == %v", err)
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestAbortReleasesRequests(t *testing.T) {
+	sim := simgo.NewSimulation()
+	res := simgo.NewResource(sim, 1)
+	finished := false
+
+	ev := sim.Event()
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(res.Request())
+		res.Request()
+		proc.Wait(ev)
+		t.Error("Process was executed too far")
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		req := res.Request()
+		proc.Wait(proc.Timeout(5))
+		assertf(t, !req.Triggered(), "req.Triggered() == true")
+
+		ev.Abort()
+
+		assertf(t, req.Triggered(), "req.Triggered() == false")
+		proc.Wait(req)
+		req.Release()
+		assertf(t, res.Available() == 1, "res.Available() == %d", res.Available())
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestUse(t *testing.T) {
+	sim := simgo.NewSimulation()
+	res := simgo.NewResource(sim, 1)
+	finished := 0
+
+	for i := 0; i < 2; i++ {
+		sim.Process(func(proc simgo.Process) {
+			err := proc.Use(res, func() {
+				assertf(t, res.Available() == 0, "res.Available() == %d", res.Available())
+				proc.Wait(proc.Timeout(5))
+			})
+			assertf(t, err == nil, "err == %v", err)
+			finished++
+		})
+	}
+
+	sim.Run()
+	assertf(t, finished == 2, "finished == %d", finished)
+	assertf(t, sim.Now() == 10, "sim.Now() == %f", sim.Now())
+	assertf(t, res.Available() == 1, "res.Available() == %d", res.Available())
+}
+
+func TestUseAborted(t *testing.T) {
+	sim := simgo.NewSimulation()
+	res := simgo.NewResource(sim, 1)
+
+	ev := sim.Event()
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Use(res, func() {
+			proc.Wait(ev)
+			t.Error("Process was executed too far")
+		})
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(5))
+		ev.Abort()
+	})
+
+	sim.Run()
+	assertf(t, res.Available() == 1, "res.Available() == %d", res.Available())
+}
+
+func TestUseInterrupted(t *testing.T) {
+	sim := simgo.NewSimulation()
+	res := simgo.NewResource(sim, 0)
+	finished := false
+
+	victim := sim.Process(func(proc simgo.Process) {
+		err := proc.Use(res, func() {
+			t.Error("Resource was used")
+		})
+		_, ok := err.(*simgo.Interrupt)
+		assertf(t, ok, "err == %v", err)
+		finished = true
+	})
+
+	victim.Interrupt(nil)
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func BenchmarkProcessWait(b *testing.B) {
+	sim := simgo.NewSimulation()
+	defer sim.Shutdown()
+
+	sim.Process(func(proc simgo.Process) {
+		for i := 0; i < b.N; i++ {
+			proc.Wait(proc.Timeout(1))
+		}
+	})
+
+	b.ResetTimer()
+	sim.Run()
+}
+
+func TestProcessIntrospection(t *testing.T) {
+	sim := simgo.NewSimulation()
+	ev := sim.Event()
+
+	proc1 := sim.Process(func(proc simgo.Process) {
+		assertf(t, proc.State() == simgo.ProcessRunning, "proc.State() == %v", proc.State())
+		proc.Wait(proc.Timeout(5))
+	})
+	proc1.SetName("worker")
+
+	proc2 := sim.Process(func(proc simgo.Process) {
+		proc.Wait(ev)
+	})
+
+	assertf(t, proc1.ID() == 0, "proc1.ID() == %d", proc1.ID())
+	assertf(t, proc2.ID() == 1, "proc2.ID() == %d", proc2.ID())
+	assertf(t, proc1.Name() == "worker", "proc1.Name() == %s", proc1.Name())
+	assertf(t, proc2.Name() == "", "proc2.Name() == %s", proc2.Name())
+	assertf(t, proc1.State() == simgo.ProcessCreated, "proc1.State() == %v", proc1.State())
+	assertf(t, proc1.String() == `process 0 "worker" (created)`, "proc1.String() == %s", proc1.String())
+
+	sim.RunUntil(2)
+	assertf(t, proc1.State() == simgo.ProcessWaiting, "proc1.State() == %v", proc1.State())
+	assertf(t, proc2.String() == "process 1 (waiting)", "proc2.String() == %s", proc2.String())
+
+	procs := sim.Processes()
+	assertf(t, len(procs) == 2, "len(procs) == %d", len(procs))
+	assertf(t, procs[0].ID() == 0 && procs[1].ID() == 1, "procs == %v", procs)
+	assertf(t, procs[0].Started() == 0, "procs[0].Started() == %f", procs[0].Started())
+
+	sim.Run()
+	assertf(t, proc1.State() == simgo.ProcessFinished, "proc1.State() == %v", proc1.State())
+
+	ev.Abort()
+	assertf(t, proc2.State() == simgo.ProcessAborted, "proc2.State() == %v", proc2.State())
+	assertf(t, len(sim.Processes()) == 0, "len(sim.Processes()) == %d", len(sim.Processes()))
+}
+
+func TestProcessStateShutdown(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	proc := sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(5))
+	})
+
+	sim.RunUntil(1)
+	sim.Shutdown()
+	assertf(t, proc.State() == simgo.ProcessAborted, "proc.State() == %v", proc.State())
+}
+
+func TestProcessPanic(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	proc := sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(3))
+		panic("boom")
+	})
+	proc.SetName("faulty")
+
+	defer func() {
+		r := recover()
+		p, ok := r.(*simgo.ProcessPanic)
+		assertf(t, ok, "r == %v", r)
+		if !ok {
+			return
+		}
+
+		assertf(t, p.Value == "boom", "p.Value == %v", p.Value)
+		assertf(t, p.Now == 3, "p.Now == %f", p.Now)
+		assertf(t, p.Process.ID() == proc.ID(), "p.Process == %v", p.Process)
+		assertf(t, strings.HasPrefix(p.Error(), `panic in process 0 "faulty" (aborted) at 3.000000: boom`), "p.Error() == %s", p.Error())
+		assertf(t, strings.Contains(string(p.Stack), "TestProcessPanic"), "p.Stack == %s", p.Stack)
+		assertf(t, proc.State() == simgo.ProcessAborted, "proc.State() == %v", proc.State())
+	}()
+
+	sim.Run()
+	t.Error("Run did not panic")
+}
+
+func TestProcessPanicRunContext(t *testing.T) {
+	sim := simgo.NewSimulation()
+	failure := errors.New("failure")
+
+	sim.Process(func(proc simgo.Process) {
+		for i := 0; i < 5; i++ {
+			proc.Wait(proc.Timeout(1))
+		}
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(2.5))
+		panic(failure)
+	})
+
+	res := sim.RunContext(context.Background())
+	assertf(t, res.Reason == simgo.Panicked, "res.Reason == %v", res.Reason)
+	assertf(t, errors.Is(res.Err, failure), "res.Err == %v", res.Err)
+	assertf(t, res.Events == 4, "res.Events == %d", res.Events)
+
+	var p *simgo.ProcessPanic
+	assertf(t, errors.As(res.Err, &p), "res.Err == %v", res.Err)
+	assertf(t, p != nil && p.Now == 2.5, "p == %v", p)
+}
+
+func TestProcessPanicNested(t *testing.T) {
+	sim := simgo.NewSimulation()
+	ev := sim.Event()
+
+	faulty := sim.Process(func(proc simgo.Process) {
+		// the cleanup panics while the process is aborted
+		defer func() { panic("boom") }()
+		proc.Wait(ev)
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(1))
+		// resumes the faulty process from within this process
+		ev.Abort()
+		t.Error("Process was resumed after panic")
+	})
+
+	res := sim.RunContext(context.Background())
+	assertf(t, res.Reason == simgo.Panicked, "res.Reason == %v", res.Reason)
+
+	var p *simgo.ProcessPanic
+	assertf(t, errors.As(res.Err, &p), "res.Err == %v", res.Err)
+	assertf(t, p != nil && p.Process.ID() == faulty.ID(), "p == %v", p)
+}
+
+func TestProcessAbortRecovered(t *testing.T) {
+	sim := simgo.NewSimulation()
+	res := simgo.NewResource(sim, 1)
+	ev := sim.Event()
+	recovered := false
+
+	proc := sim.Process(func(proc simgo.Process) {
+		proc.Wait(res.Request())
+
+		func() {
+			defer func() {
+				recovered = recover() != nil
+			}()
+			proc.Wait(ev)
+		}()
+
+		// the process must be stopped again when it waits
+		proc.Wait(proc.Timeout(1))
+		t.Error("Process was resumed after abort")
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(1))
+		ev.Abort()
+	})
+
+	sim.Run()
+	assertf(t, recovered, "recovered == false")
+	assertf(t, proc.Aborted(), "proc.Aborted() == false")
+	assertf(t, proc.State() == simgo.ProcessAborted, "proc.State() == %v", proc.State())
+	assertf(t, res.Available() == 1, "res.Available() == %d", res.Available())
+	assertf(t, len(sim.Processes()) == 0, "len(sim.Processes()) == %d", len(sim.Processes()))
+}
+
+func TestProcessAbortRecoveredReturns(t *testing.T) {
+	sim := simgo.NewSimulation()
+	ev := sim.Event()
+
+	proc := sim.Process(func(proc simgo.Process) {
+		defer func() { recover() }()
+		proc.Wait(ev)
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(1))
+		ev.Abort()
+	})
+
+	sim.Run()
+	assertf(t, proc.Aborted(), "proc.Aborted() == false")
+	assertf(t, proc.State() == simgo.ProcessAborted, "proc.State() == %v", proc.State())
+}
@@ -0,0 +1,42 @@
+package simgo
+
+// Process1 starts a new process with the given runner, which receives the
+// given additional argument. Unlike (*Simulation).ProcessReflect, the argument
+// is checked at compile time.
+//
+// See (*Simulation).Process for further documentation.
+func Process1[A any](sim *Simulation, runner func(proc Process, a A), a A) Process {
+	return sim.Process(func(proc Process) {
+		runner(proc, a)
+	})
+}
+
+// Process2 starts a new process with the given runner, which receives the
+// given two additional arguments.
+//
+// See Process1 for further documentation.
+func Process2[A, B any](sim *Simulation, runner func(proc Process, a A, b B), a A, b B) Process {
+	return sim.Process(func(proc Process) {
+		runner(proc, a, b)
+	})
+}
+
+// Process3 starts a new process with the given runner, which receives the
+// given three additional arguments.
+//
+// See Process1 for further documentation.
+func Process3[A, B, C any](sim *Simulation, runner func(proc Process, a A, b B, c C), a A, b B, c C) Process {
+	return sim.Process(func(proc Process) {
+		runner(proc, a, b, c)
+	})
+}
+
+// Process4 starts a new process with the given runner, which receives the
+// given four additional arguments.
+//
+// See Process1 for further documentation.
+func Process4[A, B, C, D any](sim *Simulation, runner func(proc Process, a A, b B, c C, d D), a A, b B, c C, d D) Process {
+	return sim.Process(func(proc Process) {
+		runner(proc, a, b, c, d)
+	})
+}
@@ -0,0 +1,35 @@
+package simgo_test
+
+import (
+	"testing"
+
+	"github.com/fschuetz04/simgo"
+)
+
+func TestProcessArgs(t *testing.T) {
+	sim := simgo.NewSimulation()
+	finished := 0
+
+	simgo.Process1(sim, func(proc simgo.Process, a int) {
+		assertf(t, a == 1, "a == %d", a)
+		finished++
+	}, 1)
+
+	simgo.Process2(sim, func(proc simgo.Process, a int, b string) {
+		assertf(t, a == 1 && b == "b", "a == %d, b == %s", a, b)
+		finished++
+	}, 1, "b")
+
+	simgo.Process3(sim, func(proc simgo.Process, a int, b string, c float64) {
+		assertf(t, a == 1 && b == "b" && c == 3, "a == %d, b == %s, c == %f", a, b, c)
+		finished++
+	}, 1, "b", 3)
+
+	simgo.Process4(sim, func(proc simgo.Process, a int, b string, c float64, d bool) {
+		assertf(t, a == 1 && b == "b" && c == 3 && d, "a == %d, b == %s, c == %f, d == %t", a, b, c, d)
+		finished++
+	}, 1, "b", 3, true)
+
+	sim.Run()
+	assertf(t, finished == 4, "finished == %d", finished)
+}
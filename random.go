@@ -0,0 +1,212 @@
+package simgo
+
+import (
+	"hash/fnv"
+	"math/rand"
+)
+
+// MRG32k3a parameters by L'Ecuyer (1999).
+const (
+	mrgM1   = 4294967087
+	mrgM2   = 4294944443
+	mrgA12  = 1403580
+	mrgA13n = 810728
+	mrgA21  = 527612
+	mrgA23n = 1370589
+	mrgNorm = 1.0 / (mrgM1 + 1)
+)
+
+// mrgMatrix is a 3x3 matrix used to advance one component of the MRG32k3a
+// state.
+type mrgMatrix [3][3]uint64
+
+var (
+	// mrgA1 advances the first component by one step.
+	mrgA1 = mrgMatrix{{0, 1, 0}, {0, 0, 1}, {mrgM1 - mrgA13n, mrgA12, 0}}
+
+	// mrgA2 advances the second component by one step.
+	mrgA2 = mrgMatrix{{0, 1, 0}, {0, 0, 1}, {mrgM2 - mrgA23n, 0, mrgA21}}
+
+	// mrgA1p127 advances the first component by 2^127 steps, which is the
+	// distance between two streams.
+	mrgA1p127 = mrgA1.powPow2(127, mrgM1)
+
+	// mrgA2p127 advances the second component by 2^127 steps.
+	mrgA2p127 = mrgA2.powPow2(127, mrgM2)
+)
+
+// Stream is a stream of random numbers generated by the combined multiple
+// recursive generator MRG32k3a by L'Ecuyer, which has a period of about 2^191.
+//
+// Streams returned from (*Simulation).Stream start 2^127 steps apart, so they
+// are independent for all practical purposes. Stream implements
+// rand.Source64, so it can be used with rand.New.
+type Stream struct {
+	// s1 holds the state of the first component.
+	s1 [3]uint64
+
+	// s2 holds the state of the second component.
+	s2 [3]uint64
+}
+
+// WithSeed returns an option which sets the seed of the random streams of the
+// simulation. Simulations with the same seed produce the same random numbers
+// for streams with the same name.
+func WithSeed(seed uint64) Option {
+	return func(sim *Simulation) {
+		sim.seed = newStream(seed)
+	}
+}
+
+// Stream returns the random stream with the given name. Calling Stream with the
+// same name again returns the same stream.
+//
+// The initial state of a stream depends only on the seed of the simulation and
+// the name of the stream, not on the order in which streams are created. This
+// makes it possible to use common random numbers for different scenarios.
+func (sim *Simulation) Stream(name string) *Stream {
+	if stream, ok := sim.streams[name]; ok {
+		return stream
+	}
+
+	if sim.streams == nil {
+		sim.streams = make(map[string]*Stream)
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+
+	stream := sim.seed.jump(hash.Sum64())
+	sim.streams[name] = stream
+	return stream
+}
+
+// Rand returns a *rand.Rand using the random stream with the given name. See
+// (*Simulation).Stream for further documentation.
+func (sim *Simulation) Rand(name string) *rand.Rand {
+	return rand.New(sim.Stream(name))
+}
+
+// Float64 returns a random number in the open interval (0, 1).
+func (stream *Stream) Float64() float64 {
+	return float64(stream.next()) * mrgNorm
+}
+
+// Uint64 returns a random 64-bit unsigned integer.
+func (stream *Stream) Uint64() uint64 {
+	return stream.next()<<32 | stream.next()
+}
+
+// Int63 returns a random non-negative 63-bit integer.
+func (stream *Stream) Int63() int64 {
+	return int64(stream.Uint64() >> 1)
+}
+
+// Seed resets the stream to the state derived from the given seed, which is the
+// state of a stream of a simulation created using WithSeed.
+func (stream *Stream) Seed(seed int64) {
+	*stream = *newStream(uint64(seed))
+}
+
+// next advances the state and returns a random integer in [1, mrgM1].
+func (stream *Stream) next() uint64 {
+	s1, s2 := &stream.s1, &stream.s2
+
+	// adding a multiple of the modulus keeps the differences non-negative
+	// without overflowing
+	p1 := (mrgA12*s1[1] + mrgA13n*(mrgM1-s1[0])) % mrgM1
+	s1[0], s1[1], s1[2] = s1[1], s1[2], p1
+
+	p2 := (mrgA21*s2[2] + mrgA23n*(mrgM2-s2[0])) % mrgM2
+	s2[0], s2[1], s2[2] = s2[1], s2[2], p2
+
+	if p1 > p2 {
+		return p1 - p2
+	}
+
+	return p1 - p2 + mrgM1
+}
+
+// jump returns a new stream whose state is advanced by n * 2^127 steps.
+func (stream *Stream) jump(n uint64) *Stream {
+	m1 := mrgMatrix{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	m2 := m1
+
+	for a1, a2 := mrgA1p127, mrgA2p127; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			m1 = m1.mul(a1, mrgM1)
+			m2 = m2.mul(a2, mrgM2)
+		}
+
+		a1 = a1.mul(a1, mrgM1)
+		a2 = a2.mul(a2, mrgM2)
+	}
+
+	return &Stream{s1: m1.apply(stream.s1, mrgM1), s2: m2.apply(stream.s2, mrgM2)}
+}
+
+// newStream returns the stream derived from the given seed using SplitMix64.
+func newStream(seed uint64) *Stream {
+	next := func() uint64 {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+
+	stream := &Stream{}
+	for stream.s1 == [3]uint64{} {
+		for i := range stream.s1 {
+			stream.s1[i] = next() % mrgM1
+		}
+	}
+	for stream.s2 == [3]uint64{} {
+		for i := range stream.s2 {
+			stream.s2[i] = next() % mrgM2
+		}
+	}
+
+	return stream
+}
+
+// defaultStream returns the stream with the default seed of L'Ecuyer, which is
+// used if no seed is given.
+func defaultStream() *Stream {
+	return &Stream{
+		s1: [3]uint64{12345, 12345, 12345},
+		s2: [3]uint64{12345, 12345, 12345},
+	}
+}
+
+// mul returns the product of the matrices modulo m.
+func (a mrgMatrix) mul(b mrgMatrix, m uint64) mrgMatrix {
+	var c mrgMatrix
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				c[i][j] = (c[i][j] + a[i][k]*b[k][j]%m) % m
+			}
+		}
+	}
+	return c
+}
+
+// apply returns the product of the matrix and the given vector modulo m.
+func (a mrgMatrix) apply(v [3]uint64, m uint64) [3]uint64 {
+	var w [3]uint64
+	for i := 0; i < 3; i++ {
+		for k := 0; k < 3; k++ {
+			w[i] = (w[i] + a[i][k]*v[k]%m) % m
+		}
+	}
+	return w
+}
+
+// powPow2 returns the matrix to the power of 2^e modulo m.
+func (a mrgMatrix) powPow2(e int, m uint64) mrgMatrix {
+	for i := 0; i < e; i++ {
+		a = a.mul(a, m)
+	}
+	return a
+}
@@ -0,0 +1,85 @@
+package simgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStreamDefaultSeed(t *testing.T) {
+	stream := defaultStream()
+
+	// first numbers of MRG32k3a with the default seed of L'Ecuyer
+	for _, expected := range []float64{0.12701112204657714, 0.3185275653967945, 0.3091860155832701} {
+		x := stream.Float64()
+		assertf(t, math.Abs(x-expected) < 1e-15, "stream.Float64() == %.17f", x)
+	}
+}
+
+func TestStreamJump(t *testing.T) {
+	stream := newStream(42)
+
+	// jump matrices published by L'Ecuyer et al. (2002)
+	a1p127 := mrgMatrix{
+		{2427906178, 3580155704, 949770784},
+		{226153695, 1230515664, 3580155704},
+		{1988835001, 986791581, 1230515664},
+	}
+	a2p127 := mrgMatrix{
+		{1464411153, 277697599, 1610723613},
+		{32183930, 1464411153, 1022607788},
+		{2824425944, 32183930, 2093834863},
+	}
+	assertf(t, mrgA1p127 == a1p127, "mrgA1p127 == %v", mrgA1p127)
+	assertf(t, mrgA2p127 == a2p127, "mrgA2p127 == %v", mrgA2p127)
+
+	// jumping by two streams must match jumping by one stream twice
+	jumped := stream.jump(2)
+	twice := stream.jump(1).jump(1)
+	assertf(t, *jumped == *twice, "jumped == %v, twice == %v", jumped, twice)
+
+	// applying the one-step matrices must match advancing the stream
+	a1, a2 := mrgA1.mul(mrgA1, mrgM1), mrgA2.mul(mrgA2, mrgM2)
+	s1, s2 := a1.apply(stream.s1, mrgM1), a2.apply(stream.s2, mrgM2)
+	stream.next()
+	stream.next()
+	assertf(t, stream.s1 == s1 && stream.s2 == s2, "stream == %v", stream)
+}
+
+func TestStreamNamed(t *testing.T) {
+	sim1 := NewSimulation(WithSeed(7))
+	sim2 := NewSimulation(WithSeed(7))
+	sim3 := NewSimulation(WithSeed(8))
+
+	// streams with the same name are the same, independent of creation order
+	a1 := sim1.Stream("a")
+	b1 := sim1.Stream("b")
+	b2 := sim2.Stream("b")
+	a2 := sim2.Stream("a")
+	a3 := sim3.Stream("a")
+
+	assertf(t, sim1.Stream("a") == a1, "sim1.Stream(\"a\") != a1")
+
+	for i := 0; i < 100; i++ {
+		xa1, xa2, xa3 := a1.Float64(), a2.Float64(), a3.Float64()
+		xb1, xb2 := b1.Float64(), b2.Float64()
+
+		assertf(t, xa1 == xa2, "xa1 == %f, xa2 == %f", xa1, xa2)
+		assertf(t, xb1 == xb2, "xb1 == %f, xb2 == %f", xb1, xb2)
+		assertf(t, xa1 != xb1, "xa1 == xb1 == %f", xa1)
+		assertf(t, xa1 != xa3, "xa1 == xa3 == %f", xa1)
+		assertf(t, xa1 > 0 && xa1 < 1, "xa1 == %f", xa1)
+	}
+}
+
+func TestStreamRand(t *testing.T) {
+	sim := NewSimulation()
+	r := sim.Rand("rand")
+
+	sum := 0.0
+	for i := 0; i < 10_000; i++ {
+		sum += r.ExpFloat64()
+	}
+
+	mean := sum / 10_000
+	assertf(t, math.Abs(mean-1) < 0.05, "mean == %f", mean)
+}
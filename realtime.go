@@ -0,0 +1,127 @@
+package simgo
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RealTimeConfig configures (*Simulation).RunRealTime.
+type RealTimeConfig struct {
+	// Scale is the wall-clock duration of one unit of simulation time.
+	// Defaults to one second.
+	Scale time.Duration
+
+	// Tolerance is the maximum duration the simulation may fall behind the
+	// wall-clock time before it is lagging. Defaults to 10 milliseconds, so
+	// the jitter of sleeping and scheduling is not reported as lag.
+	Tolerance time.Duration
+
+	// OnLag is called whenever the simulation is lagging before an event is
+	// processed, with the current simulation time and the lag. May be nil.
+	OnLag func(now float64, lag time.Duration)
+
+	// Strict makes (*Simulation).RunRealTime stop and return a *LagError as
+	// soon as the simulation is lagging.
+	Strict bool
+}
+
+// LagError is returned from (*Simulation).RunRealTime in strict mode if the
+// simulation falls behind the wall-clock time.
+type LagError struct {
+	// Now is the simulation time at which the lag was detected.
+	Now float64
+
+	// Lag is the duration the simulation is behind the wall-clock time.
+	Lag time.Duration
+}
+
+// Error returns a description of the lag.
+func (err *LagError) Error() string {
+	return fmt.Sprintf("simulation lagging behind wall-clock time by %v at %f", err.Lag, err.Now)
+}
+
+// RunRealTime runs the simulation like (*Simulation).RunUntil, but
+// synchronizes the simulation time with the wall-clock time: Before an event is
+// processed, it sleeps until the wall-clock time corresponding to the time of
+// the event. The simulation time at the start of the call corresponds to the
+// wall-clock time at the start of the call. The target time may be infinite
+// to run until the event queue is empty.
+//
+// The run ends early if (*Simulation).Stop is called.
+//
+// If the processing of events takes longer than the simulation time allows,
+// the simulation is lagging. Then, the OnLag callback of the config is called
+// and, in strict mode, a *LagError is returned without processing the next
+// event. Returns nil otherwise.
+//
+// Panics if the given target time is smaller than the current simulation
+// time or the scale or tolerance is negative.
+func (sim *Simulation) RunRealTime(target float64, cfg RealTimeConfig) error {
+	if target < sim.Now() {
+		panic(fmt.Sprintf("(*Simulation).RunRealTime: target must not be smaller than the current simulation time: %f < %f", target, sim.Now()))
+	}
+
+	if cfg.Scale < 0 {
+		panic(fmt.Sprintf("(*Simulation).RunRealTime: scale must not be negative: %v", cfg.Scale))
+	}
+
+	if cfg.Tolerance < 0 {
+		panic(fmt.Sprintf("(*Simulation).RunRealTime: tolerance must not be negative: %v", cfg.Tolerance))
+	}
+
+	if cfg.Scale == 0 {
+		cfg.Scale = time.Second
+	}
+
+	if cfg.Tolerance == 0 {
+		cfg.Tolerance = 10 * time.Millisecond
+	}
+
+	sim.stopRequested = false
+	start := time.Now()
+	startSim := sim.Now()
+	due := func(t float64) time.Time {
+		offset := (t - startSim) * float64(cfg.Scale)
+		if offset >= math.MaxInt64 {
+			// the offset does not fit into a duration
+			return start.Add(math.MaxInt64)
+		}
+
+		return start.Add(time.Duration(offset))
+	}
+
+	for ev := sim.events.min(); ev != nil && ev.time < target; ev = sim.events.min() {
+		wait := time.Until(due(ev.time))
+
+		if wait > 0 {
+			time.Sleep(wait)
+		} else if lag := -wait; lag > cfg.Tolerance {
+			if cfg.OnLag != nil {
+				cfg.OnLag(sim.Now(), lag)
+			}
+
+			if cfg.Strict {
+				return &LagError{Now: sim.Now(), Lag: lag}
+			}
+		}
+
+		sim.Step()
+
+		if sim.stopRequested {
+			sim.stopRequested = false
+			return nil
+		}
+	}
+
+	if math.IsInf(target, 1) {
+		return nil
+	}
+
+	if wait := time.Until(due(target)); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	sim.now = target
+	return nil
+}
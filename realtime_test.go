@@ -0,0 +1,123 @@
+package simgo_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fschuetz04/simgo"
+)
+
+func TestRunRealTime(t *testing.T) {
+	sim := simgo.NewSimulation()
+	var elapsed []time.Duration
+	start := time.Now()
+
+	sim.Process(func(proc simgo.Process) {
+		for i := 0; i < 3; i++ {
+			proc.Wait(proc.Timeout(10))
+			elapsed = append(elapsed, time.Since(start))
+		}
+	})
+
+	err := sim.RunRealTime(40, simgo.RealTimeConfig{Scale: time.Millisecond})
+	total := time.Since(start)
+
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, sim.Now() == 40, "sim.Now() == %f", sim.Now())
+	assertf(t, len(elapsed) == 3, "len(elapsed) == %d", len(elapsed))
+	for i, d := range elapsed {
+		expected := time.Duration(10*(i+1)) * time.Millisecond
+		assertf(t, d >= expected, "elapsed[%d] == %v", i, d)
+	}
+	assertf(t, total >= 40*time.Millisecond, "total == %v", total)
+}
+
+func TestRunRealTimeUntilEmpty(t *testing.T) {
+	sim := simgo.NewSimulation()
+	sim.Timeout(5)
+
+	err := sim.RunRealTime(math.Inf(1), simgo.RealTimeConfig{Scale: time.Millisecond})
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, sim.Now() == 5, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunRealTimeLag(t *testing.T) {
+	sim := simgo.NewSimulation()
+	lags := 0
+
+	sim.Process(func(proc simgo.Process) {
+		for i := 0; i < 3; i++ {
+			// processing takes longer than the simulation time allows
+			time.Sleep(5 * time.Millisecond)
+			proc.Wait(proc.Timeout(1))
+		}
+	})
+
+	err := sim.RunRealTime(10, simgo.RealTimeConfig{
+		Scale:     time.Millisecond,
+		Tolerance: time.Millisecond,
+		OnLag: func(now float64, lag time.Duration) {
+			lags++
+		},
+	})
+
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, lags > 0, "lags == %d", lags)
+	assertf(t, sim.Now() == 10, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunRealTimeStrict(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(1))
+		time.Sleep(10 * time.Millisecond)
+		proc.Wait(proc.Timeout(1))
+	})
+
+	err := sim.RunRealTime(10, simgo.RealTimeConfig{
+		Scale:     time.Millisecond,
+		Tolerance: time.Millisecond,
+		Strict:    true,
+	})
+
+	var lagErr *simgo.LagError
+	assertf(t, errors.As(err, &lagErr), "err == %v", err)
+	assertf(t, lagErr != nil && lagErr.Now == 1, "lagErr == %v", lagErr)
+	assertf(t, lagErr != nil && lagErr.Lag > time.Millisecond, "lagErr == %v", lagErr)
+}
+
+func TestRunRealTimeStrictDefaultTolerance(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	sim.Process(func(proc simgo.Process) {
+		for i := 0; i < 5; i++ {
+			proc.Wait(proc.Timeout(1))
+		}
+	})
+
+	// the jitter of sleeping must not be reported as lag
+	err := sim.RunRealTime(5, simgo.RealTimeConfig{Scale: time.Millisecond, Strict: true})
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, sim.Now() == 5, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunRealTimeLargeTime(t *testing.T) {
+	sim := simgo.NewSimulation()
+	sim.Timeout(1e12)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sim.RunRealTime(math.Inf(1), simgo.RealTimeConfig{Scale: time.Hour, Strict: true})
+	}()
+
+	// the wall-clock time of the event does not fit into a duration, so the
+	// run sleeps instead of lagging
+	select {
+	case err := <-done:
+		t.Errorf("RunRealTime returned before the event was due: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
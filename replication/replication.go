@@ -0,0 +1,232 @@
+// Package replication runs independent replications of a simulation model in
+// parallel and estimates the means of its output metrics with confidence
+// intervals.
+//
+// Each replication gets its own simulation seeded differently, so the
+// replications are independent and reproducible:
+//
+//	res := replication.Run(100, func(sim *simgo.Simulation) map[string]float64 {
+//		server := simgo.NewResource(sim, 1)
+//		// set up the model using the server ...
+//		sim.RunUntil(1000)
+//		return map[string]float64{"utilization": server.Stats().Utilization}
+//	})
+//	est := res.Metrics["utilization"]
+//	fmt.Printf("%f +/- %f\n", est.Mean, est.HalfWidth)
+package replication
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/fschuetz04/simgo"
+	"github.com/fschuetz04/simgo/stats"
+)
+
+// Model sets up a model in the given simulation, runs the simulation and
+// returns the output metrics of the replication by name.
+type Model func(sim *simgo.Simulation) map[string]float64
+
+// Option configures a run of replications.
+type Option func(cfg *config)
+
+// config holds the configuration of a run of replications.
+type config struct {
+	// workers is the number of replications run concurrently.
+	workers int
+
+	// seed is the seed of the first replication.
+	seed uint64
+
+	// level is the confidence level of the confidence intervals.
+	level float64
+}
+
+// WithWorkers sets the number of replications run concurrently. Defaults to
+// runtime.GOMAXPROCS(0). Panics if the number is not positive.
+func WithWorkers(workers int) Option {
+	if workers <= 0 {
+		panic(fmt.Sprintf("WithWorkers: workers must be > 0: %d", workers))
+	}
+
+	return func(cfg *config) {
+		cfg.workers = workers
+	}
+}
+
+// WithSeed sets the seed of the first replication. Replication i is seeded
+// with seed + i. Defaults to 0.
+func WithSeed(seed uint64) Option {
+	return func(cfg *config) {
+		cfg.seed = seed
+	}
+}
+
+// WithConfidence sets the confidence level of the confidence intervals like
+// 0.95. Defaults to 0.95. Panics if the level is not between 0 and 1.
+func WithConfidence(level float64) Option {
+	if level <= 0 || level >= 1 {
+		panic(fmt.Sprintf("WithConfidence: level must be between 0 and 1: %f", level))
+	}
+
+	return func(cfg *config) {
+		cfg.level = level
+	}
+}
+
+// Estimate is the estimate of the mean of an output metric over all
+// replications.
+type Estimate struct {
+	// Count is the number of replications which returned the metric.
+	Count int
+
+	// Mean is the mean over all replications.
+	Mean float64
+
+	// StdDev is the standard deviation over all replications.
+	StdDev float64
+
+	// HalfWidth is the half-width of the confidence interval for the mean.
+	HalfWidth float64
+
+	// Lower is the lower bound of the confidence interval for the mean.
+	Lower float64
+
+	// Upper is the upper bound of the confidence interval for the mean.
+	Upper float64
+}
+
+// Result is the result of a run of replications.
+type Result struct {
+	// Metrics holds the estimates of the output metrics by name.
+	Metrics map[string]Estimate
+
+	// Outputs holds the output metrics of each replication in order. The
+	// outputs of failed replications are nil.
+	Outputs []map[string]float64
+
+	// Errors holds the error of each failed replication in order, or nil for
+	// successful replications.
+	Errors []error
+}
+
+// Failed returns the number of failed replications.
+func (res *Result) Failed() int {
+	failed := 0
+	for _, err := range res.Errors {
+		if err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Names returns the names of all output metrics in sorted order.
+func (res *Result) Names() []string {
+	names := make([]string, 0, len(res.Metrics))
+	for name := range res.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs the given number of replications of the given model concurrently.
+// Each replication uses its own simulation, which is shut down after the model
+// returned.
+//
+// If the model panics, for example because the runner of a process panics,
+// the replication fails. The panic is recovered and stored as error of the
+// replication, and the other replications are not affected. Failed
+// replications are not included in the estimates.
+//
+// Panics if the number of replications is not positive.
+func Run(replications int, model Model, opts ...Option) *Result {
+	if replications <= 0 {
+		panic(fmt.Sprintf("replication.Run: replications must be > 0: %d", replications))
+	}
+
+	cfg := config{workers: runtime.GOMAXPROCS(0), level: 0.95}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	outputs := make([]map[string]float64, replications)
+	errs := make([]error, replications)
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.workers && w < replications; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				outputs[i], errs[i] = runOne(model, i, cfg.seed+uint64(i))
+			}
+		}()
+	}
+
+	for i := 0; i < replications; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	res := summarize(outputs, cfg.level)
+	res.Errors = errs
+	return res
+}
+
+// runOne runs the replication with the given index of the given model with the
+// given seed. Returns an error if the model panics.
+func runOne(model Model, i int, seed uint64) (output map[string]float64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			output = nil
+			if rErr, ok := r.(error); ok {
+				err = fmt.Errorf("replication %d with seed %d failed: %w", i, seed, rErr)
+			} else {
+				err = fmt.Errorf("replication %d with seed %d failed: %v", i, seed, r)
+			}
+		}
+	}()
+
+	sim := simgo.NewSimulation(simgo.WithSeed(seed))
+	defer sim.Shutdown()
+
+	return model(sim), nil
+}
+
+// summarize estimates the means of all output metrics with confidence
+// intervals with the given confidence level.
+func summarize(outputs []map[string]float64, level float64) *Result {
+	tallies := make(map[string]*stats.Tally)
+	for _, output := range outputs {
+		for name, value := range output {
+			tally, ok := tallies[name]
+			if !ok {
+				tally = &stats.Tally{}
+				tallies[name] = tally
+			}
+			tally.Add(value)
+		}
+	}
+
+	metrics := make(map[string]Estimate, len(tallies))
+	for name, tally := range tallies {
+		mean := tally.Mean()
+		halfWidth := tally.HalfWidth(level)
+		metrics[name] = Estimate{
+			Count:     tally.Count(),
+			Mean:      mean,
+			StdDev:    tally.StdDev(),
+			HalfWidth: halfWidth,
+			Lower:     mean - halfWidth,
+			Upper:     mean + halfWidth,
+		}
+	}
+
+	return &Result{Metrics: metrics, Outputs: outputs}
+}
@@ -0,0 +1,117 @@
+package replication_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/fschuetz04/simgo"
+	"github.com/fschuetz04/simgo/replication"
+)
+
+func assertf(t *testing.T, condition bool, format string, args ...any) {
+	t.Helper()
+	if !condition {
+		t.Errorf(format, args...)
+	}
+}
+
+// model simulates a single server queue and returns the number of served
+// customers and a random draw.
+func model(sim *simgo.Simulation) map[string]float64 {
+	server := simgo.NewResource(sim, 1)
+	arrivals := sim.Stream("arrivals")
+	served := 0
+
+	sim.Process(func(proc simgo.Process) {
+		for {
+			proc.Wait(proc.Timeout(-math.Log(arrivals.Float64())))
+			proc.Process(func(proc simgo.Process) {
+				proc.Use(server, func() {
+					proc.Wait(proc.Timeout(0.5))
+				})
+				served++
+			})
+		}
+	})
+
+	sim.RunUntil(100)
+
+	return map[string]float64{
+		"served": float64(served),
+		"draw":   sim.Stream("draw").Float64(),
+	}
+}
+
+func TestRun(t *testing.T) {
+	res := replication.Run(50, model, replication.WithWorkers(4))
+
+	assertf(t, len(res.Outputs) == 50, "len(res.Outputs) == %d", len(res.Outputs))
+
+	names := res.Names()
+	assertf(t, len(names) == 2 && names[0] == "draw" && names[1] == "served", "names == %v", names)
+
+	draw := res.Metrics["draw"]
+	assertf(t, draw.Count == 50, "draw.Count == %d", draw.Count)
+	assertf(t, draw.Lower < 0.5 && 0.5 < draw.Upper, "draw == %+v", draw)
+	assertf(t, draw.HalfWidth > 0, "draw.HalfWidth == %f", draw.HalfWidth)
+
+	served := res.Metrics["served"]
+	assertf(t, served.Mean > 50 && served.Mean < 150, "served.Mean == %f", served.Mean)
+}
+
+func TestRunReproducible(t *testing.T) {
+	a := replication.Run(8, model, replication.WithSeed(7), replication.WithWorkers(3))
+	b := replication.Run(8, model, replication.WithSeed(7), replication.WithWorkers(1))
+
+	for i := range a.Outputs {
+		assertf(t, a.Outputs[i]["draw"] == b.Outputs[i]["draw"], "a.Outputs[%d] == %v, b.Outputs[%d] == %v", i, a.Outputs[i], i, b.Outputs[i])
+	}
+
+	// replications use different seeds
+	assertf(t, a.Outputs[0]["draw"] != a.Outputs[1]["draw"], "a.Outputs[0] == a.Outputs[1]")
+}
+
+func TestRunMissingMetric(t *testing.T) {
+	res := replication.Run(10, func(sim *simgo.Simulation) map[string]float64 {
+		if sim.Stream("x").Float64() < 0.5 {
+			return map[string]float64{"low": 1}
+		}
+		return nil
+	})
+
+	low := res.Metrics["low"]
+	assertf(t, low.Count > 0 && low.Count < 10, "low.Count == %d", low.Count)
+	assertf(t, low.Mean == 1, "low.Mean == %f", low.Mean)
+}
+
+func TestRunPanic(t *testing.T) {
+	res := replication.Run(6, func(sim *simgo.Simulation) map[string]float64 {
+		fail := sim.Stream("fail").Float64() < 0.5
+
+		sim.Process(func(proc simgo.Process) {
+			proc.Wait(proc.Timeout(1))
+			if fail {
+				panic("boom")
+			}
+		})
+
+		sim.Run()
+		return map[string]float64{"ok": 1}
+	})
+
+	failed := res.Failed()
+	assertf(t, failed > 0 && failed < 6, "res.Failed() == %d", failed)
+	assertf(t, res.Metrics["ok"].Count == 6-failed, "res.Metrics[\"ok\"].Count == %d", res.Metrics["ok"].Count)
+
+	for i, err := range res.Errors {
+		if err == nil {
+			continue
+		}
+
+		var p *simgo.ProcessPanic
+		assertf(t, errors.As(err, &p), "res.Errors[%d] == %v", i, err)
+		assertf(t, p != nil && p.Value == "boom" && p.Now == 1, "p == %v", p)
+		assertf(t, res.Outputs[i] == nil, "res.Outputs[%d] == %v", i, res.Outputs[i])
+	}
+}
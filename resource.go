@@ -1,21 +1,151 @@
 package simgo
 
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/fschuetz04/simgo/stats"
+)
+
 // Resource can be used by a limited number of processes at a time.
+//
+// Pending requests are served in order of their priority, where a lower value
+// means a higher priority. Requests with the same priority are served in a
+// first-in first-out order.
 type Resource struct {
 	// sim is the reference to the simulation.
 	sim *Simulation
 
-	// reqs holds the list of pending request events.
-	reqs []*Event
+	// reqs holds the queue of pending requests.
+	reqs requestQueue
+
+	// users holds the granted requests in the order they were granted.
+	users []*Request
 
 	// available is the number of available instances.
 	available int
+
+	// nextID holds the next ID for queueing a new request.
+	nextID uint64
+
+	// preemptive is whether new requests can preempt users with a lower
+	// priority.
+	preemptive bool
+
+	// capacity is the total number of instances.
+	capacity int
+
+	// busy accumulates the number of instances in use.
+	busy *stats.TimeWeighted
+
+	// queue accumulates statistics about the queue of pending requests.
+	queue queueMonitor
+}
+
+// ResourceStats holds statistics about a resource since the last reset.
+type ResourceStats struct {
+	// MeanBusy is the time-weighted average number of instances in use.
+	MeanBusy float64
+
+	// MaxBusy is the maximum number of instances in use.
+	MaxBusy int
+
+	// Utilization is MeanBusy divided by the total number of instances.
+	Utilization float64
+
+	// Queue holds statistics about the queue of pending requests.
+	Queue QueueStats
+}
+
+// Request is a request for an instance of a resource, returned from
+// (*Resource).Request and (*Resource).RequestWithPriority. The underlying event
+// is triggered when the request is granted.
+//
+// A granted request must be released exactly once using (*Request).Release. A
+// pending request can be cancelled using (*Request).Cancel.
+type Request struct {
+	// Event is the underlying event.
+	*Event
+
+	// res is the resource the request was made for.
+	res *Resource
+
+	// priority is the priority of the request. A lower value means a higher
+	// priority.
+	priority int
+
+	// id is an incremental ID to sort requests with the same priority by
+	// insertion order.
+	id uint64
+
+	// index is the position of the request in the queue of pending requests,
+	// or -1 if the request is not pending.
+	index int
+
+	// owner is the process which made the request, or nil if the request was
+	// not made from within a process.
+	owner *Process
+
+	// requested is the simulation time at which the request was made.
+	requested float64
+
+	// since is the simulation time at which the request was granted.
+	since float64
+
+	// released is whether the request was released.
+	released bool
+
+	// preempted is whether the request was preempted.
+	preempted bool
+}
+
+// Preempted is the cause of the interrupt of a process whose instance of a
+// preemptive resource was taken by a request with a higher priority.
+//
+// The instance is already released when the process is interrupted, so
+// releasing the preempted request has no effect.
+type Preempted struct {
+	// By is the process which made the preempting request, or nil if the
+	// request was not made from within a process.
+	By *Process
+
+	// UsageSince is the simulation time at which the preempted request was
+	// granted.
+	UsageSince float64
+
+	// Resource is the resource the preempted request was granted by.
+	Resource *Resource
 }
 
 // NewResource creates a resource for the given simulation with the given
 // number of available instances.
 func NewResource(sim *Simulation, available int) *Resource {
-	return &Resource{sim: sim, available: available}
+	return newResource(sim, available, false)
+}
+
+// NewPreemptiveResource creates a preemptive resource for the given simulation
+// with the given number of available instances.
+//
+// If no instance is available, a new request preempts the granted request with
+// the lowest priority if the new request has a strictly higher priority. Among
+// granted requests with the same priority, the one granted last is preempted.
+// The process which made the preempted request is interrupted with a
+// *Preempted as cause.
+func NewPreemptiveResource(sim *Simulation, available int) *Resource {
+	return newResource(sim, available, true)
+}
+
+// newResource creates a resource for the given simulation with the given
+// number of available instances.
+func newResource(sim *Simulation, available int, preemptive bool) *Resource {
+	return &Resource{
+		sim:        sim,
+		available:  available,
+		preemptive: preemptive,
+		capacity:   available,
+		busy:       stats.NewTimeWeighted(sim, 0),
+		queue:      newQueueMonitor(sim),
+	}
 }
 
 // Available returns the number of available instances of the resource.
@@ -23,10 +153,65 @@ func (res *Resource) Available() int {
 	return res.available
 }
 
-// Request requests an instance of the resource.
-func (res *Resource) Request() *Event {
-	req := res.sim.Event()
-	res.reqs = append(res.reqs, req)
+// Stats returns statistics about the resource since it was created or since
+// the last call to (*Resource).ResetStats.
+func (res *Resource) Stats() ResourceStats {
+	stats := ResourceStats{
+		MeanBusy: res.busy.Mean(),
+		MaxBusy:  int(res.busy.Max()),
+		Queue:    res.queue.stats(),
+	}
+
+	if res.capacity > 0 {
+		stats.Utilization = stats.MeanBusy / float64(res.capacity)
+	}
+
+	return stats
+}
+
+// ResetStats resets the statistics about the resource, for example after a
+// warm-up period.
+func (res *Resource) ResetStats() {
+	res.busy.Reset()
+	res.queue.reset()
+}
+
+// Request requests an instance of the resource with the default priority 0.
+func (res *Resource) Request() *Request {
+	return res.RequestWithPriority(0)
+}
+
+// RequestWithPriority requests an instance of the resource with the given
+// priority. A lower value means a higher priority.
+func (res *Resource) RequestWithPriority(priority int) *Request {
+	req := &Request{
+		Event:     res.sim.Event(),
+		res:       res,
+		priority:  priority,
+		id:        res.nextID,
+		owner:     res.sim.active,
+		requested: res.sim.Now(),
+	}
+	res.nextID++
+
+	req.AddAbortHandler(func(*Event) {
+		// the request is cancelled, so remove it from the queue
+		if req.index >= 0 {
+			heap.Remove(&res.reqs, req.index)
+		}
+		req.disown()
+		res.observe()
+	})
+
+	heap.Push(&res.reqs, req)
+
+	if req.owner != nil {
+		req.owner.data.requests = append(req.owner.data.requests, req)
+	}
+
+	if res.preemptive && res.available == 0 {
+		res.preempt(req)
+	}
 
 	res.triggerRequests()
 
@@ -34,23 +219,196 @@ func (res *Resource) Request() *Event {
 }
 
 // Release releases an instance of the resource.
+//
+// The instance released is the one granted first to the active process. If the
+// active process holds no instance, the instance granted first is released.
+// Panics if no instance is in use.
+//
+// Deprecated: Use (*Request).Release instead, which releases the instance
+// granted to a particular request.
 func (res *Resource) Release() {
+	if len(res.users) == 0 {
+		panic("(*Resource).Release: no instance is in use")
+	}
+
+	user := res.users[0]
+	for _, other := range res.users {
+		if other.owner != nil && res.sim.active != nil && other.owner.data == res.sim.active.data {
+			user = other
+			break
+		}
+	}
+
+	user.Release()
+}
+
+// Release releases the instance granted to the request.
+//
+// If the request was preempted, the instance is already released and calling
+// Release has no effect. Panics if the request was not granted or was already
+// released.
+func (req *Request) Release() {
+	if !req.Triggered() {
+		panic("(*Request).Release: request was not granted")
+	}
+
+	if req.preempted {
+		return
+	}
+
+	if req.released {
+		panic("(*Request).Release: request was already released")
+	}
+
+	req.released = true
+	req.res.removeUser(req)
+	req.res.available++
+	req.disown()
+
+	req.res.triggerRequests()
+}
+
+// Cancel cancels the request while it is pending by aborting the underlying
+// event. The request is removed from the queue of the resource.
+//
+// Panics if the request was already granted. Use (*Request).Release instead.
+func (req *Request) Cancel() {
+	if req.Triggered() {
+		panic("(*Request).Cancel: request was already granted")
+	}
+
+	req.Abort()
+}
+
+// String returns a description of the request.
+func (req *Request) String() string {
+	return fmt.Sprintf("request with priority %d for resource with %d of %d instances in use", req.priority, req.res.capacity-req.res.available, req.res.capacity)
+}
+
+// disown removes the request from the requests of the process which made the
+// request.
+func (req *Request) disown() {
+	if req.owner == nil {
+		return
+	}
+
+	reqs := req.owner.data.requests
+	for i, other := range reqs {
+		if other == req {
+			req.owner.data.requests = append(reqs[:i], reqs[i+1:]...)
+			return
+		}
+	}
+}
+
+// preempt preempts the granted request with the lowest priority in favor of
+// the given request if the given request has a strictly higher priority.
+func (res *Resource) preempt(req *Request) {
+	if len(res.users) == 0 {
+		return
+	}
+
+	// find the granted request with the lowest priority, preferring the one
+	// granted last
+	victim := res.users[0]
+	for _, user := range res.users {
+		if user.priority >= victim.priority {
+			victim = user
+		}
+	}
+
+	if victim.priority <= req.priority {
+		return
+	}
+
+	victim.preempted = true
+	res.removeUser(victim)
 	res.available++
+	victim.disown()
 
-	res.triggerRequests()
+	if victim.owner != nil && victim.owner.Pending() {
+		cause := &Preempted{UsageSince: victim.since, Resource: res}
+		if req.owner != nil {
+			by := *req.owner
+			cause.By = &by
+		}
+		victim.owner.Interrupt(cause)
+	}
+}
+
+// removeUser removes the given request from the granted requests.
+func (res *Resource) removeUser(req *Request) {
+	for i, user := range res.users {
+		if user == req {
+			res.users = append(res.users[:i], res.users[i+1:]...)
+			return
+		}
+	}
 }
 
 // triggerRequests triggers pending request events until no more instances are
 // available.
 func (res *Resource) triggerRequests() {
 	for len(res.reqs) > 0 && res.available > 0 {
-		req := res.reqs[0]
-		res.reqs = res.reqs[1:]
+		req := heap.Pop(&res.reqs).(*Request)
 
 		if !req.Trigger() {
 			continue
 		}
 
 		res.available--
+
+		req.since = res.sim.Now()
+		res.users = append(res.users, req)
+		res.queue.serve(req.requested)
+	}
+
+	res.observe()
+}
+
+// observe updates the statistics with the current state of the resource.
+func (res *Resource) observe() {
+	res.busy.Update(float64(res.capacity - res.available))
+	res.queue.length.Update(float64(len(res.reqs)))
+}
+
+// requestQueue holds pending requests ordered by priority and insertion order.
+type requestQueue []*Request
+
+// Len returns the number of pending requests.
+func (rq requestQueue) Len() int {
+	return len(rq)
+}
+
+// Less returns whether the request at position i is served before the request
+// at position j.
+func (rq requestQueue) Less(i, j int) bool {
+	if rq[i].priority != rq[j].priority {
+		return rq[i].priority < rq[j].priority
 	}
+
+	return rq[i].id < rq[j].id
+}
+
+// Swap swaps the requests at position i and j.
+func (rq requestQueue) Swap(i, j int) {
+	rq[i], rq[j] = rq[j], rq[i]
+	rq[i].index = i
+	rq[j].index = j
+}
+
+// Push appends the given request at the back.
+func (rq *requestQueue) Push(item any) {
+	req := item.(*Request)
+	req.index = len(*rq)
+	*rq = append(*rq, req)
+}
+
+// Pop removes and returns the request at the back.
+func (rq *requestQueue) Pop() any {
+	n := len(*rq)
+	req := (*rq)[n-1]
+	req.index = -1
+	*rq = (*rq)[:n-1]
+	return req
 }
@@ -1,6 +1,9 @@
 package simgo
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestResourceRequestRelease(t *testing.T) {
 	sim := NewSimulation()
@@ -28,3 +31,218 @@ func TestResourceRequestRelease(t *testing.T) {
 		assertf(t, req_ev.Triggered(), "req_ev.Triggered() == false")
 	})
 }
+
+func TestResourcePriority(t *testing.T) {
+	sim := NewSimulation()
+	res := NewResource(sim, 1)
+
+	sim.Process(func(proc Process) {
+		// resource is not empty, immediate request
+		req_ev := res.Request()
+		assertf(t, req_ev.Triggered(), "req_ev.Triggered() == false")
+
+		// resource is empty, requests queued
+		low_ev := res.RequestWithPriority(2)
+		high_ev1 := res.RequestWithPriority(1)
+		high_ev2 := res.RequestWithPriority(1)
+
+		assertf(t, len(res.reqs) == 3, "len(res.reqs) == %d", len(res.reqs))
+
+		// request with the highest priority is served first
+		res.Release()
+
+		assertf(t, high_ev1.Triggered(), "high_ev1.Triggered() == false")
+		assertf(t, !high_ev2.Triggered(), "high_ev2.Triggered() == true")
+		assertf(t, !low_ev.Triggered(), "low_ev.Triggered() == true")
+
+		// requests with the same priority are served in insertion order
+		res.Release()
+
+		assertf(t, high_ev2.Triggered(), "high_ev2.Triggered() == false")
+		assertf(t, !low_ev.Triggered(), "low_ev.Triggered() == true")
+
+		res.Release()
+
+		assertf(t, low_ev.Triggered(), "low_ev.Triggered() == false")
+		assertf(t, len(res.reqs) == 0, "len(res.reqs) == %d", len(res.reqs))
+	})
+
+	sim.Run()
+}
+
+func TestResourcePreemption(t *testing.T) {
+	sim := NewSimulation()
+	res := NewPreemptiveResource(sim, 1)
+	finished := 0
+
+	low := sim.Process(func(proc Process) {
+		req := res.RequestWithPriority(1)
+		proc.Wait(req)
+		err := proc.Wait(proc.Timeout(10))
+		assertf(t, proc.Now() == 3, "proc.Now() == %f", proc.Now())
+
+		interrupt, ok := err.(*Interrupt)
+		assertf(t, ok, "err == %v", err)
+		if ok {
+			preempted, ok := interrupt.Cause.(*Preempted)
+			assertf(t, ok, "interrupt.Cause == %v", interrupt.Cause)
+			if ok {
+				assertf(t, preempted.UsageSince == 0, "preempted.UsageSince == %f", preempted.UsageSince)
+				assertf(t, preempted.Resource == res, "preempted.Resource != res")
+				assertf(t, preempted.By != nil, "preempted.By == nil")
+			}
+		}
+
+		// releasing a preempted request has no effect
+		req.Release()
+
+		finished++
+	})
+
+	sim.Process(func(proc Process) {
+		proc.Wait(proc.Timeout(3))
+
+		// request with the same priority does not preempt
+		same := res.RequestWithPriority(1)
+		assertf(t, !same.Triggered(), "same.Triggered() == true")
+		same.Abort()
+
+		// request with a higher priority preempts
+		req := res.RequestWithPriority(0)
+		assertf(t, req.Triggered(), "req.Triggered() == false")
+		assertf(t, low.Pending(), "low.Pending() == false")
+
+		proc.Wait(req)
+		proc.Wait(proc.Timeout(1))
+		req.Release()
+		assertf(t, res.Available() == 1, "res.Available() == %d", res.Available())
+		finished++
+	})
+
+	sim.Process(func(proc Process) {
+		proc.Wait(proc.Timeout(5))
+		proc.Wait(res.RequestWithPriority(1))
+		err := proc.Wait(proc.Timeout(10))
+		assertf(t, proc.Now() == 6, "proc.Now() == %f", proc.Now())
+
+		interrupt, ok := err.(*Interrupt)
+		assertf(t, ok, "err == %v", err)
+		if ok {
+			preempted, ok := interrupt.Cause.(*Preempted)
+			assertf(t, ok, "interrupt.Cause == %v", interrupt.Cause)
+			if ok {
+				assertf(t, preempted.By == nil, "preempted.By == %v", preempted.By)
+			}
+			assertf(t, !strings.Contains(interrupt.Error(), "PANIC"), "interrupt.Error() == %s", interrupt.Error())
+		}
+
+		finished++
+	})
+
+	// request with a higher priority made outside of a process preempts
+	sim.Timeout(6).AddHandler(func(*Event) {
+		res.RequestWithPriority(0)
+	})
+
+	sim.Run()
+	assertf(t, finished == 3, "finished == %d", finished)
+}
+
+func TestRequestRelease(t *testing.T) {
+	sim := NewSimulation()
+	res := NewResource(sim, 1)
+
+	sim.Process(func(proc Process) {
+		req1 := res.Request()
+		req2 := res.Request()
+		proc.Wait(req1)
+
+		assertf(t, len(res.users) == 1, "len(res.users) == %d", len(res.users))
+		assertf(t, !req2.Triggered(), "req2.Triggered() == true")
+
+		req1.Release()
+
+		assertf(t, len(res.users) == 1, "len(res.users) == %d", len(res.users))
+		assertf(t, req2.Triggered(), "req2.Triggered() == false")
+
+		req2.Release()
+
+		assertf(t, len(res.users) == 0, "len(res.users) == %d", len(res.users))
+		assertf(t, res.Available() == 1, "res.Available() == %d", res.Available())
+	})
+
+	sim.Run()
+}
+
+func TestRequestReleaseTwice(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := NewSimulation()
+	res := NewResource(sim, 1)
+
+	req := res.Request()
+	req.Release()
+	req.Release()
+}
+
+func TestRequestReleasePending(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := NewSimulation()
+	res := NewResource(sim, 0)
+
+	req := res.Request()
+	req.Release()
+}
+
+func TestRequestCancel(t *testing.T) {
+	sim := NewSimulation()
+	res := NewResource(sim, 1)
+
+	req1 := res.Request()
+	req2 := res.Request()
+	req3 := res.Request()
+
+	assertf(t, len(res.reqs) == 2, "len(res.reqs) == %d", len(res.reqs))
+
+	req2.Cancel()
+
+	assertf(t, len(res.reqs) == 1, "len(res.reqs) == %d", len(res.reqs))
+	assertf(t, req2.Aborted(), "req2.Aborted() == false")
+
+	req1.Release()
+
+	assertf(t, req3.Triggered(), "req3.Triggered() == false")
+	assertf(t, len(res.reqs) == 0, "len(res.reqs) == %d", len(res.reqs))
+}
+
+func TestRequestCancelGranted(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := NewSimulation()
+	res := NewResource(sim, 1)
+
+	req := res.Request()
+	req.Cancel()
+}
+
+func TestResourceReleaseUnused(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := NewSimulation()
+	res := NewResource(sim, 1)
+
+	res.Release()
+}
@@ -0,0 +1,54 @@
+package simgo
+
+// ResultProcess is a process whose runner returns a result of type T.
+//
+// To start a process with a result, use ProcessResult:
+//
+//	func measure(proc simgo.Process) (float64, error) {
+//	    start := proc.Now()
+//	    proc.Wait(proc.Timeout(5))
+//	    return proc.Now() - start, nil
+//	}
+//	child := simgo.ProcessResult(proc.Simulation, measure)
+//	duration, err := simgo.WaitResult(proc, child)
+//
+// ResultProcess encapsulates Process, so it can be waited for and interrupted
+// like any other process.
+type ResultProcess[T any] struct {
+	// Process is the underlying process.
+	Process
+}
+
+// ProcessResult starts a new process with the given runner, which returns a
+// result and an error.
+//
+// As soon as the runner returns, the underlying event is triggered with the
+// result as its value. If the runner returns a non-nil error, the underlying
+// event fails with this error instead and the result is discarded.
+//
+// See (*Simulation).Process for further documentation.
+func ProcessResult[T any](sim *Simulation, runner func(proc Process) (T, error)) ResultProcess[T] {
+	return ResultProcess[T]{Process: sim.start(func(proc Process) (any, error) {
+		return runner(proc)
+	})}
+}
+
+// Result returns the result and the error returned from the runner. Returns
+// the zero value and nil if the process has not finished yet.
+func (proc ResultProcess[T]) Result() (T, error) {
+	result, _ := proc.Value().(T)
+	return result, proc.Err()
+}
+
+// WaitResult waits in the given process until the given other process is
+// finished like (Process).Wait and returns the result of the other process.
+//
+// Returns the zero value and an error if (Process).Wait returns an error.
+func WaitResult[T any](proc Process, other ResultProcess[T]) (T, error) {
+	if err := proc.Wait(other); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return other.Result()
+}
@@ -0,0 +1,71 @@
+package simgo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fschuetz04/simgo"
+)
+
+func TestWaitResult(t *testing.T) {
+	sim := simgo.NewSimulation()
+	finished := false
+
+	child := simgo.ProcessResult(sim, func(proc simgo.Process) (float64, error) {
+		proc.Wait(proc.Timeout(5))
+		return proc.Now() * 2, nil
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		result, err := simgo.WaitResult(proc, child)
+		assertf(t, err == nil, "err == %v", err)
+		assertf(t, result == 10, "result == %f", result)
+
+		result, err = child.Result()
+		assertf(t, err == nil, "err == %v", err)
+		assertf(t, result == 10, "result == %f", result)
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestWaitResultFailed(t *testing.T) {
+	sim := simgo.NewSimulation()
+	errFailed := errors.New("failed")
+	finished := false
+
+	child := simgo.ProcessResult(sim, func(proc simgo.Process) (int, error) {
+		proc.Wait(proc.Timeout(5))
+		return 5, errFailed
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		result, err := simgo.WaitResult(proc, child)
+		assertf(t, err == errFailed, "err == %v", err)
+		assertf(t, result == 0, "result == %d", result)
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestResultPending(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	child := simgo.ProcessResult(sim, func(proc simgo.Process) (string, error) {
+		return "result", nil
+	})
+
+	result, err := child.Result()
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, result == "", "result == %s", result)
+
+	sim.Run()
+
+	result, err = child.Result()
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, result == "result", "result == %s", result)
+}
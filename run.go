@@ -0,0 +1,243 @@
+package simgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrAborted is returned from (*Simulation).RunUntilEvent if the awaitable
+	// is aborted.
+	ErrAborted = errors.New("awaitable aborted")
+
+	// ErrQueueEmpty is returned from (*Simulation).RunUntilEvent if the event
+	// queue is empty before the awaitable is processed.
+	ErrQueueEmpty = errors.New("event queue empty before awaitable was processed")
+
+	// ErrStopped is returned from (*Simulation).RunUntilEvent if
+	// (*Simulation).Stop is called before the awaitable is processed.
+	ErrStopped = errors.New("simulation stopped before awaitable was processed")
+)
+
+// StopReason is the reason why a run of a simulation ended.
+type StopReason int
+
+const (
+	// QueueEmpty means that the event queue is empty.
+	QueueEmpty StopReason = iota
+
+	// TargetReached means that the target time given by Until was reached.
+	TargetReached
+
+	// Stopped means that (*Simulation).Stop was called.
+	Stopped
+
+	// Cancelled means that the context was cancelled.
+	Cancelled
+
+	// EventLimit means that the number of events given by MaxEvents was
+	// processed.
+	EventLimit
+
+	// Panicked means that the runner of a process panicked.
+	Panicked
+)
+
+// String returns the name of the stop reason.
+func (reason StopReason) String() string {
+	switch reason {
+	case QueueEmpty:
+		return "queue empty"
+	case TargetReached:
+		return "target reached"
+	case Stopped:
+		return "stopped"
+	case Cancelled:
+		return "cancelled"
+	case EventLimit:
+		return "event limit"
+	case Panicked:
+		return "panicked"
+	default:
+		return fmt.Sprintf("StopReason(%d)", int(reason))
+	}
+}
+
+// RunResult describes how a run of a simulation ended.
+type RunResult struct {
+	// Reason is the reason why the run ended.
+	Reason StopReason
+
+	// Events is the number of events processed during the run.
+	Events int
+
+	// Err is the error of the context if the run was cancelled, a
+	// *DeadlockError if the event queue is empty while processes are still
+	// waiting, a *ProcessPanic if the runner of a process panicked, or nil
+	// otherwise.
+	Err error
+}
+
+// RunOption configures a run started by (*Simulation).RunContext.
+type RunOption func(cfg *runConfig)
+
+// runConfig holds the configuration of a run.
+type runConfig struct {
+	// target is the time until which the simulation is run.
+	target float64
+
+	// maxEvents is the maximum number of events processed, or -1 if there is
+	// no limit.
+	maxEvents int
+}
+
+// Until runs the simulation until the next event is scheduled at or after the
+// given target time like (*Simulation).RunUntil.
+func Until(target float64) RunOption {
+	return func(cfg *runConfig) {
+		cfg.target = target
+	}
+}
+
+// MaxEvents stops the run after the given number of events was processed.
+// Panics if the given number is negative.
+func MaxEvents(n int) RunOption {
+	if n < 0 {
+		panic(fmt.Sprintf("MaxEvents: n must not be negative: %d", n))
+	}
+
+	return func(cfg *runConfig) {
+		cfg.maxEvents = n
+	}
+}
+
+// RunContext runs the simulation until the event queue is empty, the given
+// context is cancelled, (*Simulation).Stop is called, or a limit given by the
+// options is reached. The context is checked before each event.
+//
+// If a target time is given by Until and no event is left before it, the
+// current simulation time is set to the target time and the reason is
+// TargetReached, even if the event queue is empty.
+//
+// If the runner of a process panics, the run ends with the reason Panicked and
+// the *ProcessPanic as error. The simulation should not be run further in
+// this case.
+//
+// Panics if the target time is smaller than the current simulation time.
+func (sim *Simulation) RunContext(ctx context.Context, opts ...RunOption) (result RunResult) {
+	cfg := runConfig{target: math.Inf(1), maxEvents: -1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.target < sim.Now() {
+		panic(fmt.Sprintf("(*Simulation).RunContext: target must not be smaller than the current simulation time: %f < %f", cfg.target, sim.Now()))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p, ok := r.(*ProcessPanic)
+			if !ok {
+				panic(r)
+			}
+
+			result.Reason = Panicked
+			result.Err = p
+		}
+	}()
+
+	sim.run(ctx, cfg, &result)
+	if result.Reason == QueueEmpty {
+		result.Err = sim.CheckDeadlock()
+	}
+
+	return result
+}
+
+// RunUntilEvent runs the simulation until the given awaitable is processed or
+// aborted. Events scheduled at the same time as the awaitable which are
+// processed after it are left in the event queue for further runs.
+//
+// Returns the value of the awaitable once it is processed, or nil and the
+// error of the awaitable if it failed. Returns nil and ErrAborted if the
+// awaitable is aborted, ErrQueueEmpty if the event queue is empty before, or
+// ErrStopped if (*Simulation).Stop is called before.
+func (sim *Simulation) RunUntilEvent(ev Awaitable) (any, error) {
+	sim.stopRequested = false
+
+	for {
+		if ev.Processed() {
+			if err := ev.Err(); err != nil {
+				return nil, err
+			}
+			return ev.Value(), nil
+		}
+
+		if ev.Aborted() {
+			return nil, ErrAborted
+		}
+
+		if sim.stopRequested {
+			sim.stopRequested = false
+			return nil, ErrStopped
+		}
+
+		if !sim.Step() {
+			return nil, ErrQueueEmpty
+		}
+	}
+}
+
+// Stop stops the current run of the simulation after the event which is
+// currently processed. Can be called from processes and handlers. Has no
+// effect if the simulation is not running.
+func (sim *Simulation) Stop() {
+	sim.stopRequested = true
+}
+
+// run runs the simulation with the given configuration and stores how the run
+// ended in the given result. The result is updated while running, so the
+// number of processed events is correct even if a process panics.
+func (sim *Simulation) run(ctx context.Context, cfg runConfig, result *RunResult) {
+	sim.stopRequested = false
+	done := ctx.Done()
+
+	for {
+		if done != nil {
+			select {
+			case <-done:
+				result.Reason = Cancelled
+				result.Err = ctx.Err()
+				return
+			default:
+			}
+		}
+
+		if cfg.maxEvents >= 0 && result.Events >= cfg.maxEvents {
+			result.Reason = EventLimit
+			return
+		}
+
+		ev := sim.events.min()
+		if ev == nil || ev.time >= cfg.target {
+			if math.IsInf(cfg.target, 1) {
+				result.Reason = QueueEmpty
+			} else {
+				sim.now = cfg.target
+				result.Reason = TargetReached
+			}
+			return
+		}
+
+		sim.Step()
+		result.Events++
+
+		if sim.stopRequested {
+			sim.stopRequested = false
+			result.Reason = Stopped
+			return
+		}
+	}
+}
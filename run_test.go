@@ -0,0 +1,216 @@
+package simgo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fschuetz04/simgo"
+)
+
+func TestRunContextQueueEmpty(t *testing.T) {
+	sim := simgo.NewSimulation()
+	sim.Timeout(3)
+	sim.Timeout(5)
+
+	res := sim.RunContext(context.Background())
+	assertf(t, res.Reason == simgo.QueueEmpty, "res.Reason == %v", res.Reason)
+	assertf(t, res.Events == 2, "res.Events == %d", res.Events)
+	assertf(t, res.Err == nil, "res.Err == %v", res.Err)
+	assertf(t, sim.Now() == 5, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunContextUntil(t *testing.T) {
+	sim := simgo.NewSimulation()
+	sim.Timeout(3)
+	sim.Timeout(5)
+
+	res := sim.RunContext(context.Background(), simgo.Until(4))
+	assertf(t, res.Reason == simgo.TargetReached, "res.Reason == %v", res.Reason)
+	assertf(t, res.Events == 1, "res.Events == %d", res.Events)
+	assertf(t, sim.Now() == 4, "sim.Now() == %f", sim.Now())
+
+	res = sim.RunContext(context.Background(), simgo.Until(10))
+	assertf(t, res.Reason == simgo.TargetReached, "res.Reason == %v", res.Reason)
+	assertf(t, res.Events == 1, "res.Events == %d", res.Events)
+	assertf(t, sim.Now() == 10, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunContextStop(t *testing.T) {
+	sim := simgo.NewSimulation()
+	steps := 0
+
+	sim.Process(func(proc simgo.Process) {
+		for {
+			proc.Wait(proc.Timeout(1))
+			steps++
+			if steps == 3 {
+				proc.Stop()
+			}
+		}
+	})
+
+	res := sim.RunContext(context.Background())
+	assertf(t, res.Reason == simgo.Stopped, "res.Reason == %v", res.Reason)
+	assertf(t, sim.Now() == 3, "sim.Now() == %f", sim.Now())
+
+	// the next run continues
+	res = sim.RunContext(context.Background(), simgo.Until(5))
+	assertf(t, res.Reason == simgo.TargetReached, "res.Reason == %v", res.Reason)
+	assertf(t, steps == 4, "steps == %d", steps)
+}
+
+func TestRunStop(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(2))
+		proc.Stop()
+		proc.Wait(proc.Timeout(2))
+	})
+
+	sim.RunUntil(10)
+	assertf(t, sim.Now() == 2, "sim.Now() == %f", sim.Now())
+
+	sim.Run()
+	assertf(t, sim.Now() == 4, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	sim := simgo.NewSimulation()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sim.Process(func(proc simgo.Process) {
+		for i := 0; ; i++ {
+			proc.Wait(proc.Timeout(1))
+			if i == 5 {
+				cancel()
+			}
+		}
+	})
+
+	res := sim.RunContext(ctx)
+	assertf(t, res.Reason == simgo.Cancelled, "res.Reason == %v", res.Reason)
+	assertf(t, errors.Is(res.Err, context.Canceled), "res.Err == %v", res.Err)
+	assertf(t, sim.Now() == 6, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunContextMaxEvents(t *testing.T) {
+	sim := simgo.NewSimulation()
+	for i := 0; i < 10; i++ {
+		sim.Timeout(float64(i))
+	}
+
+	res := sim.RunContext(context.Background(), simgo.MaxEvents(4))
+	assertf(t, res.Reason == simgo.EventLimit, "res.Reason == %v", res.Reason)
+	assertf(t, res.Events == 4, "res.Events == %d", res.Events)
+	assertf(t, sim.Now() == 3, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunContextNegative(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := simgo.NewSimulation()
+	sim.RunUntil(5)
+	sim.RunContext(context.Background(), simgo.Until(4))
+}
+
+func TestStopReasonString(t *testing.T) {
+	assertf(t, simgo.EventLimit.String() == "event limit", "simgo.EventLimit.String() == %s", simgo.EventLimit.String())
+	assertf(t, simgo.StopReason(42).String() == "StopReason(42)", "simgo.StopReason(42).String() == %s", simgo.StopReason(42).String())
+}
+
+func TestRunUntilEventProcess(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	proc := simgo.ProcessResult(sim, func(proc simgo.Process) (int, error) {
+		proc.Wait(proc.Timeout(5))
+		return 42, nil
+	})
+	other := sim.Timeout(8)
+
+	value, err := sim.RunUntilEvent(proc)
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, value == 42, "value == %v", value)
+	assertf(t, sim.Now() == 5, "sim.Now() == %f", sim.Now())
+	assertf(t, other.Pending(), "other.Pending() == false")
+
+	// the rest of the queue is left intact
+	sim.Run()
+	assertf(t, other.Processed(), "other.Processed() == false")
+	assertf(t, sim.Now() == 8, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunUntilEventAnyOf(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev1 := sim.Timeout(3)
+	ev2 := sim.Timeout(7)
+
+	_, err := sim.RunUntilEvent(sim.AnyOf(ev1, ev2))
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, sim.Now() == 3, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunUntilEventProcessed(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev := sim.Event()
+	ev.Succeed("done")
+	sim.Run()
+
+	value, err := sim.RunUntilEvent(ev)
+	assertf(t, err == nil, "err == %v", err)
+	assertf(t, value == "done", "value == %v", value)
+}
+
+func TestRunUntilEventFailed(t *testing.T) {
+	sim := simgo.NewSimulation()
+	failure := errors.New("failure")
+
+	ev := sim.Event()
+	ev.Fail(failure)
+
+	value, err := sim.RunUntilEvent(ev)
+	assertf(t, value == nil, "value == %v", value)
+	assertf(t, errors.Is(err, failure), "err == %v", err)
+}
+
+func TestRunUntilEventAborted(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	ev := sim.Event()
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(2))
+		ev.Abort()
+	})
+
+	_, err := sim.RunUntilEvent(ev)
+	assertf(t, errors.Is(err, simgo.ErrAborted), "err == %v", err)
+	assertf(t, sim.Now() == 2, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunUntilEventQueueEmpty(t *testing.T) {
+	sim := simgo.NewSimulation()
+	sim.Timeout(2)
+
+	_, err := sim.RunUntilEvent(sim.Event())
+	assertf(t, errors.Is(err, simgo.ErrQueueEmpty), "err == %v", err)
+	assertf(t, sim.Now() == 2, "sim.Now() == %f", sim.Now())
+}
+
+func TestRunUntilEventStopped(t *testing.T) {
+	sim := simgo.NewSimulation()
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(1))
+		proc.Stop()
+	})
+
+	_, err := sim.RunUntilEvent(sim.Timeout(5))
+	assertf(t, errors.Is(err, simgo.ErrStopped), "err == %v", err)
+	assertf(t, sim.Now() == 1, "sim.Now() == %f", sim.Now())
+}
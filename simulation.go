@@ -1,10 +1,13 @@
 package simgo
 
 import (
-	"container/heap"
+	"context"
 	"fmt"
+	"iter"
+	"math"
 	"reflect"
-	"runtime"
+	"runtime/debug"
+	"sort"
 )
 
 // Simulation runs a discrete-event simulation. To create a new simulation, use
@@ -13,19 +16,52 @@ type Simulation struct {
 	// now holds the current simulation time.
 	now float64
 
-	// eq holds the event queue.
-	eq eventQueue
+	// events holds all scheduled events.
+	events eventList
 
 	// nextID holds the next ID for scheduling a new event.
 	nextID uint64
 
-	// shutdown is used to shutdown all process goroutines of this simulation.
-	shutdown chan struct{}
+	// nextProcessID holds the ID of the next started process.
+	nextProcessID uint64
+
+	// live holds all processes which have not finished yet by their data.
+	live map[*processData]Process
+
+	// stopped is whether the simulation was shut down.
+	stopped bool
+
+	// stopRequested is whether (*Simulation).Stop was called during the
+	// current run.
+	stopRequested bool
+
+	// active holds the currently executed process, or nil if no process is
+	// executed.
+	active *Process
+
+	// seed is the stream all random streams are derived from.
+	seed *Stream
+
+	// streams holds the random streams by name.
+	streams map[string]*Stream
 }
 
-// NewSimulation creates a new simulation.
-func NewSimulation() *Simulation {
-	return &Simulation{shutdown: make(chan struct{})}
+// Option configures a simulation created by NewSimulation.
+type Option func(sim *Simulation)
+
+// NewSimulation creates a new simulation with the given options.
+func NewSimulation(opts ...Option) *Simulation {
+	sim := &Simulation{
+		events: &eventHeap{},
+		live:   make(map[*processData]Process),
+		seed:   defaultStream(),
+	}
+
+	for _, opt := range opts {
+		opt(sim)
+	}
+
+	return sim
 }
 
 // Now returns the current simulation time.
@@ -44,44 +80,19 @@ func (sim *Simulation) Now() float64 {
 // Returns the process. This can be used to wait for the process to finish. As
 // soon as the process finishes, the underlying event is triggered.
 func (sim *Simulation) Process(runner func(proc Process)) Process {
-	proc := Process{
-		Simulation: sim,
-		ev:         sim.Event(),
-		sync:       make(chan bool),
-	}
-
-	// schedule an event to be processed immediately and add an handler which
-	// is called when the event is processed
-	ev := sim.Timeout(0)
-	ev.AddHandler(func(*Event) {
-		// yield to the process
-		proc.sync <- true
-
-		// wait for the process
-		<-proc.sync
-	})
-
-	go func() {
-		// yield to the simulation at the end by closing
-		defer close(proc.sync)
-
-		// wait for the simulation
-		<-proc.sync
-
-		// execute the runner
+	return sim.start(func(proc Process) (any, error) {
 		runner(proc)
-
-		// process is finished trigger the underlying event
-		proc.ev.Trigger()
-	}()
-
-	return proc
+		return nil, nil
+	})
 }
 
 // ProcessReflect starts a new process with the given runner and the given
 // additional argument. This uses reflection.
 //
 // See (*Simulation).Process for further documentation.
+//
+// Deprecated: Use Process1, Process2, Process3, Process4 or a closure passed
+// to (*Simulation).Process instead, which are checked at compile time.
 func (sim *Simulation) ProcessReflect(runner any, args ...any) Process {
 	return sim.Process(func(proc Process) {
 		reflectF := reflect.ValueOf(runner)
@@ -95,13 +106,28 @@ func (sim *Simulation) ProcessReflect(runner any, args ...any) Process {
 	})
 }
 
+// Processes returns all processes which have not finished yet in the order
+// they were started.
+func (sim *Simulation) Processes() []Process {
+	procs := make([]Process, 0, len(sim.live))
+	for _, proc := range sim.live {
+		procs = append(procs, proc)
+	}
+
+	sort.Slice(procs, func(i, j int) bool {
+		return procs[i].data.id < procs[j].data.id
+	})
+
+	return procs
+}
+
 // Event creates and returns a pending event.
+//
+// An event which is never triggered or aborted keeps the processes waiting for
+// it blocked until the simulation is shut down. Use (*Simulation).CheckDeadlock
+// to detect such processes.
 func (sim *Simulation) Event() *Event {
-	ev := &Event{sim: sim}
-	runtime.SetFinalizer(ev, func(ev *Event) {
-		ev.Abort()
-	})
-	return ev
+	return &Event{sim: sim, index: -1}
 }
 
 // Timeout creates and returns a pending event which is processed after the
@@ -119,52 +145,176 @@ func (sim *Simulation) Timeout(delay float64) *Event {
 // Step sets the current simulation time to the scheduled time of the next event
 // in the event queue and processes the next event. Returns false if the event
 // queue was empty and no event was processed, true otherwise.
+//
+// If the runner of a process panics, Step panics with a *ProcessPanic.
 func (sim *Simulation) Step() bool {
-	if len(sim.eq) == 0 {
+	ev := sim.events.removeMin()
+	if ev == nil {
 		return false
 	}
 
-	qe := heap.Pop(&sim.eq).(queuedEvent)
-	sim.now = qe.time
-	qe.ev.process()
+	sim.now = ev.time
+	ev.process()
 
 	return true
 }
 
-// Run runs the simulation until the event queue is empty.
+// Run runs the simulation until the event queue is empty or (*Simulation).Stop
+// is called. To get the reason why the run ended, use (*Simulation).RunContext.
+//
+// If the runner of a process panics, Run panics with a *ProcessPanic.
 func (sim *Simulation) Run() {
-	for sim.Step() {
-	}
+	sim.run(context.Background(), runConfig{target: math.Inf(1), maxEvents: -1}, &RunResult{})
 }
 
 // RunUntil runs the simulation until the event queue is empty or the next event
 // in the event queue is scheduled at or after the given target time. Sets the
-// current simulation time to the target time at the end. Panics if the given
-// target time is smaller than the current simulation time.
+// current simulation time to the target time at the end, unless
+// (*Simulation).Stop is called before. Panics if the given target time is
+// smaller than the current simulation time.
 func (sim *Simulation) RunUntil(target float64) {
 	if target < sim.Now() {
 		panic(fmt.Sprintf("(*Simulation).RunUntil: target must not be smaller than the current simulation time: %f < %f", target, sim.Now()))
 	}
 
-	for len(sim.eq) > 0 && sim.eq[0].time < target {
-		sim.Step()
-	}
-
-	sim.now = target
+	sim.run(context.Background(), runConfig{target: target, maxEvents: -1}, &RunResult{})
 }
 
-// Shutdown shuts down all process goroutines of this simulation.
+// Shutdown stops all processes of this simulation which have not finished yet.
+// Their deferred functions are executed and their requests for resources are
+// released or cancelled. If called from a process, the process itself is
+// stopped as soon as it waits for the next time.
 func (sim *Simulation) Shutdown() {
-	close(sim.shutdown)
+	sim.stopped = true
+
+	for data := range sim.live {
+		if sim.active != nil && sim.active.data == data {
+			continue
+		}
+
+		data.stop()
+		data.state = ProcessAborted
+		delete(sim.live, data)
+	}
+}
+
+// start starts a new process with the given runner. As soon as the process
+// finishes, the underlying event is triggered with the value returned from the
+// runner, or fails with the error returned from the runner.
+//
+// See (*Simulation).Process for further documentation.
+func (sim *Simulation) start(runner func(proc Process) (any, error)) Process {
+	proc := Process{
+		Simulation: sim,
+		ev:         sim.Event(),
+		data:       &processData{id: sim.nextProcessID, started: sim.Now()},
+	}
+	sim.nextProcessID++
+
+	// schedule an event to be processed immediately and add an handler which
+	// is called when the event is processed
+	ev := sim.Timeout(0)
+	ev.AddHandler(func(*Event) {
+		proc.yield(true)
+	})
+
+	// the runner is executed in a coroutine, which switches directly between
+	// the simulation and the process without involving the scheduler
+	proc.data.next, proc.data.stop = iter.Pull(func(suspend func(struct{}) bool) {
+		proc.data.suspend = suspend
+		proc.data.state = ProcessRunning
+
+		defer delete(sim.live, proc.data)
+
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			if _, ok := r.(processExit); ok {
+				// the process was aborted or the simulation was shut down
+				return
+			}
+
+			proc.data.state = ProcessAborted
+
+			if p, ok := r.(*ProcessPanic); ok {
+				// another process resumed by this process panicked
+				panic(p)
+			}
+
+			// the runner panicked, annotate the panic and propagate it to the
+			// caller of (*Simulation).Step
+			panic(&ProcessPanic{Process: proc, Now: sim.Now(), Value: r, Stack: debug.Stack()})
+		}()
+
+		// execute the runner
+		value, err := runner(proc)
+
+		if proc.data.state == ProcessAborted {
+			// the runner recovered after the process was aborted or stopped,
+			// the underlying event must not be triggered
+			return
+		}
+
+		// process is finished, trigger the underlying event
+		proc.data.state = ProcessFinished
+		if err != nil {
+			proc.ev.Fail(err)
+		} else {
+			proc.ev.Succeed(value)
+		}
+	})
+	sim.live[proc.data] = proc
+
+	return proc
 }
 
 // schedule schedules the given event to be processed after the given delay.
-// Adds the event to the event queue.
+// Adds the event to the event queue. If the event is already scheduled, it is
+// only moved if it is scheduled earlier now.
 func (sim *Simulation) schedule(ev *Event, delay float64) {
-	heap.Push(&sim.eq, queuedEvent{
-		ev:   ev,
-		time: sim.Now() + delay,
-		id:   sim.nextID,
-	})
+	time := sim.Now() + delay
+
+	if ev.index >= 0 {
+		if time >= ev.time {
+			// event is already scheduled earlier
+			return
+		}
+
+		sim.reschedule(ev, delay)
+		return
+	}
+
+	ev.time = time
+	ev.id = sim.nextID
+	sim.nextID++
+	sim.events.insert(ev)
+}
+
+// reschedule schedules the given event to be processed after the given delay,
+// regardless of whether it is already scheduled earlier or later.
+func (sim *Simulation) reschedule(ev *Event, delay float64) {
+	if ev.index < 0 {
+		sim.schedule(ev, delay)
+		return
+	}
+
+	sim.events.remove(ev)
+	ev.time = sim.Now() + delay
+	ev.id = sim.nextID
 	sim.nextID++
+	sim.events.insert(ev)
+}
+
+// unschedule removes the given event from the event queue. Returns whether the
+// event was scheduled.
+func (sim *Simulation) unschedule(ev *Event) bool {
+	if ev.index < 0 {
+		return false
+	}
+
+	sim.events.remove(ev)
+	return true
 }
@@ -68,3 +68,81 @@ func TestTriggerTimeoutEarly(t *testing.T) {
 	sim.Run()
 	assertf(t, finished == true, "finished == false")
 }
+
+func TestShutdown(t *testing.T) {
+	sim := simgo.NewSimulation()
+	res := simgo.NewResource(sim, 1)
+	deferred := 0
+
+	for i := 0; i < 2; i++ {
+		sim.Process(func(proc simgo.Process) {
+			defer func() { deferred++ }()
+			proc.Use(res, func() {
+				proc.Wait(proc.Timeout(10))
+			})
+			t.Error("Process was resumed after shutdown")
+		})
+	}
+
+	sim.RunUntil(5)
+	sim.Shutdown()
+
+	assertf(t, deferred == 2, "deferred == %d", deferred)
+	assertf(t, res.Available() == 1, "res.Available() == %d", res.Available())
+}
+
+func TestShutdownFromProcess(t *testing.T) {
+	sim := simgo.NewSimulation()
+	stopped := false
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(10))
+		t.Error("Process was resumed after shutdown")
+	})
+
+	sim.Process(func(proc simgo.Process) {
+		defer func() { stopped = true }()
+		proc.Wait(proc.Timeout(1))
+		proc.Shutdown()
+		proc.Wait(proc.Timeout(1))
+		t.Error("Process was resumed after shutdown")
+	})
+
+	sim.Run()
+	assertf(t, stopped, "stopped == false")
+}
+
+func TestShutdownFromProcessNeverResumed(t *testing.T) {
+	sim := simgo.NewSimulation()
+	ev := sim.Event()
+
+	sim.Process(func(proc simgo.Process) {
+		proc.Shutdown()
+		// the process must be stopped instead of waiting for an event which
+		// never fires
+		proc.Wait(ev)
+		t.Error("Process was resumed after shutdown")
+	})
+
+	sim.Run()
+	assertf(t, len(sim.Processes()) == 0, "len(sim.Processes()) == %d", len(sim.Processes()))
+}
+
+func TestShutdownFromProcessTimeout(t *testing.T) {
+	sim := simgo.NewSimulation()
+	received := 0
+
+	proc := sim.Process(func(proc simgo.Process) {
+		proc.Wait(proc.Timeout(1))
+		proc.Shutdown()
+		for {
+			proc.Wait(proc.Timeout(1))
+			received++
+		}
+	})
+
+	sim.Run()
+	assertf(t, received == 0, "received == %d", received)
+	assertf(t, proc.State() == simgo.ProcessAborted, "proc.State() == %v", proc.State())
+	assertf(t, len(sim.Processes()) == 0, "len(sim.Processes()) == %d", len(sim.Processes()))
+}
@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// Histogram counts observations in bins of equal width and estimates
+// percentiles from these counts. Observations outside of the range of the bins
+// are counted separately.
+//
+// To create a new histogram, use NewHistogram.
+type Histogram struct {
+	// lower is the lower bound of the first bin.
+	lower float64
+
+	// upper is the upper bound of the last bin.
+	upper float64
+
+	// width is the width of each bin.
+	width float64
+
+	// counts holds the number of observations in each bin.
+	counts []int
+
+	// underflow is the number of observations below the lower bound.
+	underflow int
+
+	// overflow is the number of observations at or above the upper bound.
+	overflow int
+
+	// tally accumulates all observations.
+	tally Tally
+}
+
+// NewHistogram creates a histogram with the given number of bins of equal
+// width between the given lower and upper bound.
+//
+// Panics if the upper bound is not greater than the lower bound or the number
+// of bins is not positive.
+func NewHistogram(lower float64, upper float64, bins int) *Histogram {
+	if upper <= lower {
+		panic(fmt.Sprintf("NewHistogram: upper must be > lower: %f <= %f", upper, lower))
+	}
+
+	if bins <= 0 {
+		panic("NewHistogram: bins must be > 0")
+	}
+
+	return &Histogram{
+		lower:  lower,
+		upper:  upper,
+		width:  (upper - lower) / float64(bins),
+		counts: make([]int, bins),
+	}
+}
+
+// Add adds the given observation. Panics if the observation is NaN.
+func (hist *Histogram) Add(x float64) {
+	if math.IsNaN(x) {
+		panic("(*Histogram).Add: x must not be NaN")
+	}
+
+	hist.tally.Add(x)
+
+	if x < hist.lower {
+		hist.underflow++
+		return
+	}
+
+	if x >= hist.upper {
+		hist.overflow++
+		return
+	}
+
+	i := int((x - hist.lower) / hist.width)
+	if i >= len(hist.counts) {
+		// x is just below the upper bound, but the division rounded up
+		i = len(hist.counts) - 1
+	}
+
+	hist.counts[i]++
+}
+
+// Count returns the number of observations.
+func (hist *Histogram) Count() int {
+	return hist.tally.Count()
+}
+
+// Tally returns the tally of all observations, which provides their mean,
+// variance, minimum and maximum.
+func (hist *Histogram) Tally() *Tally {
+	return &hist.tally
+}
+
+// Bins returns the number of observations in each bin. The returned slice must
+// not be modified.
+func (hist *Histogram) Bins() []int {
+	return hist.counts
+}
+
+// Underflow returns the number of observations below the lower bound.
+func (hist *Histogram) Underflow() int {
+	return hist.underflow
+}
+
+// Overflow returns the number of observations at or above the upper bound.
+func (hist *Histogram) Overflow() int {
+	return hist.overflow
+}
+
+// Quantile estimates the given quantile of the observations by linear
+// interpolation within the bins, for example 0.95 for the 95th percentile.
+// Observations below or above the range of the bins are treated as if they
+// were at the minimum or maximum observation.
+//
+// Returns NaN if there are no observations. Panics if the given quantile is
+// not between 0 and 1.
+func (hist *Histogram) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(fmt.Sprintf("(*Histogram).Quantile: p must be >= 0 and <= 1: %f", p))
+	}
+
+	if hist.Count() == 0 {
+		return math.NaN()
+	}
+
+	rank := p * float64(hist.Count())
+
+	cumulative := float64(hist.underflow)
+	if rank <= cumulative {
+		return hist.tally.Min()
+	}
+
+	for i, count := range hist.counts {
+		if count == 0 || rank > cumulative+float64(count) {
+			cumulative += float64(count)
+			continue
+		}
+
+		// interpolate within the bin, restricted to the observed range
+		lower := math.Max(hist.lower+float64(i)*hist.width, hist.tally.Min())
+		upper := math.Min(hist.lower+float64(i+1)*hist.width, hist.tally.Max())
+		return lower + (upper-lower)*(rank-cumulative)/float64(count)
+	}
+
+	return hist.tally.Max()
+}
+
+// Reset removes all observations.
+func (hist *Histogram) Reset() {
+	for i := range hist.counts {
+		hist.counts[i] = 0
+	}
+
+	hist.underflow = 0
+	hist.overflow = 0
+	hist.tally.Reset()
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	hist := NewHistogram(0, 10, 10)
+
+	for i := 0; i < 100; i++ {
+		hist.Add(float64(i) / 10)
+	}
+	hist.Add(-1)
+	hist.Add(20)
+
+	assertf(t, hist.Count() == 102, "hist.Count() == %d", hist.Count())
+	assertf(t, hist.Underflow() == 1, "hist.Underflow() == %d", hist.Underflow())
+	assertf(t, hist.Overflow() == 1, "hist.Overflow() == %d", hist.Overflow())
+	assertf(t, hist.Bins()[3] == 10, "hist.Bins()[3] == %d", hist.Bins()[3])
+	assertf(t, hist.Quantile(0) == -1, "hist.Quantile(0) == %f", hist.Quantile(0))
+	assertf(t, hist.Quantile(1) == 20, "hist.Quantile(1) == %f", hist.Quantile(1))
+	assertf(t, math.Abs(hist.Quantile(0.5)-5) < 0.1, "hist.Quantile(0.5) == %f", hist.Quantile(0.5))
+
+	hist.Reset()
+
+	assertf(t, hist.Count() == 0, "hist.Count() == %d", hist.Count())
+	assertf(t, math.IsNaN(hist.Quantile(0.5)), "hist.Quantile(0.5) == %f", hist.Quantile(0.5))
+}
+
+func TestHistogramLarge(t *testing.T) {
+	hist := NewHistogram(0, 10, 10)
+
+	hist.Add(math.Inf(1))
+	hist.Add(1e300)
+	hist.Add(math.Inf(-1))
+	hist.Add(-1e300)
+
+	assertf(t, hist.Overflow() == 2, "hist.Overflow() == %d", hist.Overflow())
+	assertf(t, hist.Underflow() == 2, "hist.Underflow() == %d", hist.Underflow())
+}
+
+func TestHistogramNaN(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	hist := NewHistogram(0, 10, 10)
+	hist.Add(math.NaN())
+}
+
+func TestHistogramInvalid(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	NewHistogram(1, 1, 10)
+}
@@ -0,0 +1,142 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Quantile estimates a single quantile of a stream of observations using the
+// P² algorithm by Jain and Chlamtac, which stores only five markers instead of
+// all observations.
+//
+// To create a new estimator, use NewQuantile.
+type Quantile struct {
+	// p is the quantile to estimate.
+	p float64
+
+	// count is the number of observations.
+	count int
+
+	// heights holds the heights of the markers. Until five observations are
+	// added, it holds the sorted observations.
+	heights [5]float64
+
+	// positions holds the actual positions of the markers.
+	positions [5]float64
+
+	// desired holds the desired positions of the markers.
+	desired [5]float64
+
+	// increments holds the increments of the desired positions per
+	// observation.
+	increments [5]float64
+}
+
+// NewQuantile creates an estimator for the given quantile, for example 0.95 for
+// the 95th percentile. Panics if the given quantile is not between 0 and 1.
+func NewQuantile(p float64) *Quantile {
+	if p < 0 || p > 1 {
+		panic(fmt.Sprintf("NewQuantile: p must be >= 0 and <= 1: %f", p))
+	}
+
+	return &Quantile{
+		p:          p,
+		positions:  [5]float64{0, 1, 2, 3, 4},
+		desired:    [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4},
+		increments: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+// Add adds the given observation.
+func (q *Quantile) Add(x float64) {
+	if q.count < 5 {
+		q.heights[q.count] = x
+		q.count++
+		sort.Float64s(q.heights[:q.count])
+		return
+	}
+
+	q.count++
+
+	// find the cell containing the observation and adjust the extreme markers
+	var k int
+	switch {
+	case x < q.heights[0]:
+		q.heights[0] = x
+		k = 0
+	case x >= q.heights[4]:
+		q.heights[4] = x
+		k = 3
+	default:
+		for k = 0; x >= q.heights[k+1]; k++ {
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		q.positions[i]++
+	}
+
+	for i := range q.desired {
+		q.desired[i] += q.increments[i]
+	}
+
+	// adjust the heights of the middle markers if necessary
+	for i := 1; i <= 3; i++ {
+		d := q.desired[i] - q.positions[i]
+
+		if (d >= 1 && q.positions[i+1]-q.positions[i] > 1) || (d <= -1 && q.positions[i-1]-q.positions[i] < -1) {
+			sign := 1
+			if d < 0 {
+				sign = -1
+			}
+
+			height := q.parabolic(i, float64(sign))
+			if q.heights[i-1] >= height || height >= q.heights[i+1] {
+				height = q.linear(i, sign)
+			}
+
+			q.heights[i] = height
+			q.positions[i] += float64(sign)
+		}
+	}
+}
+
+// Count returns the number of observations.
+func (q *Quantile) Count() int {
+	return q.count
+}
+
+// Value returns the estimate of the quantile. Returns NaN if there are no
+// observations.
+func (q *Quantile) Value() float64 {
+	if q.count == 0 {
+		return math.NaN()
+	}
+
+	if q.count <= 5 {
+		// interpolate between the sorted observations
+		rank := q.p * float64(q.count-1)
+		i := int(rank)
+		if i >= q.count-1 {
+			return q.heights[q.count-1]
+		}
+
+		return q.heights[i] + (q.heights[i+1]-q.heights[i])*(rank-float64(i))
+	}
+
+	return q.heights[2]
+}
+
+// parabolic returns the height of marker i moved by d using the piecewise
+// parabolic prediction formula.
+func (q *Quantile) parabolic(i int, d float64) float64 {
+	n, h := q.positions, q.heights
+	return h[i] + d/(n[i+1]-n[i-1])*((n[i]-n[i-1]+d)*(h[i+1]-h[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-d)*(h[i]-h[i-1])/(n[i]-n[i-1]))
+}
+
+// linear returns the height of marker i moved by d using the linear prediction
+// formula.
+func (q *Quantile) linear(i int, d int) float64 {
+	return q.heights[i] + float64(d)*(q.heights[i+d]-q.heights[i])/(q.positions[i+d]-q.positions[i])
+}
@@ -0,0 +1,43 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, p := range []float64{0.05, 0.5, 0.95} {
+		q := NewQuantile(p)
+
+		for i := 0; i < 100_000; i++ {
+			q.Add(r.Float64())
+		}
+
+		assertf(t, q.Count() == 100_000, "q.Count() == %d", q.Count())
+		assertf(t, math.Abs(q.Value()-p) < 0.01, "q.Value() == %f for p == %f", q.Value(), p)
+	}
+}
+
+func TestQuantileFew(t *testing.T) {
+	q := NewQuantile(0.5)
+
+	assertf(t, math.IsNaN(q.Value()), "q.Value() == %f", q.Value())
+
+	q.Add(3)
+	q.Add(1)
+	q.Add(2)
+
+	assertf(t, q.Value() == 2, "q.Value() == %f", q.Value())
+}
+
+func TestQuantileInvalid(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	NewQuantile(1.5)
+}
@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"math"
+)
+
+// studentTQuantile returns the p-quantile of the Student's t distribution with
+// the given degrees of freedom for 0.5 <= p < 1. The cumulative distribution
+// function is inverted by bisection.
+func studentTQuantile(p float64, df float64) float64 {
+	lower, upper := 0.0, 1.0
+	for studentTCDF(upper, df) < p {
+		lower = upper
+		upper *= 2
+	}
+
+	for i := 0; i < 100 && upper-lower > 1e-12*upper; i++ {
+		mid := (lower + upper) / 2
+		if studentTCDF(mid, df) < p {
+			lower = mid
+		} else {
+			upper = mid
+		}
+	}
+
+	return (lower + upper) / 2
+}
+
+// studentTCDF returns the cumulative distribution function of the Student's t
+// distribution with the given degrees of freedom at t >= 0.
+func studentTCDF(t float64, df float64) float64 {
+	return 1 - 0.5*regIncBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// regIncBeta returns the regularized incomplete beta function I_x(a, b).
+func regIncBeta(x float64, a float64, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	if x >= 1 {
+		return 1
+	}
+
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	// the continued fraction converges quickly only for x < (a + 1) / (a + b + 2)
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction of the incomplete beta
+// function using the modified Lentz method.
+func betaContinuedFraction(x float64, a float64, b float64) float64 {
+	const (
+		tiny    = 1e-300
+		epsilon = 1e-15
+	)
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1.0; m <= 300; m++ {
+		// even step
+		num := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// odd step
+		num = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+
+	return h
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStudentTQuantile(t *testing.T) {
+	cases := []struct {
+		p        float64
+		df       float64
+		expected float64
+	}{
+		{0.975, 1, 12.706205},
+		{0.975, 9, 2.262157},
+		{0.95, 4, 2.131847},
+		{0.995, 30, 2.749996},
+		{0.975, 1e6, 1.959966},
+	}
+
+	for _, c := range cases {
+		q := studentTQuantile(c.p, c.df)
+		assertf(t, math.Abs(q-c.expected) < 1e-5, "studentTQuantile(%f, %f) == %f", c.p, c.df, q)
+	}
+}
+
+func TestTallyHalfWidth(t *testing.T) {
+	var tally Tally
+
+	assertf(t, math.IsNaN(tally.HalfWidth(0.95)), "tally.HalfWidth(0.95) == %f", tally.HalfWidth(0.95))
+
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		tally.Add(x)
+	}
+
+	// t(0.975, 7) * s / sqrt(n)
+	expected := 2.364624 * math.Sqrt(32.0/7) / math.Sqrt(8)
+	hw := tally.HalfWidth(0.95)
+	assertf(t, math.Abs(hw-expected) < 1e-5, "tally.HalfWidth(0.95) == %f", hw)
+}
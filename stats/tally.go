@@ -0,0 +1,130 @@
+// Package stats provides accumulators for output statistics of discrete-event
+// simulations.
+//
+// Tally accumulates observations like waiting times, TimeWeighted accumulates
+// values over the simulation time like queue lengths, and Histogram and
+// Quantile estimate percentiles without storing all observations.
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// Tally accumulates observations and computes their mean, variance, minimum
+// and maximum. The observations are not stored.
+//
+// The zero value is an empty tally ready to use.
+type Tally struct {
+	// count is the number of observations.
+	count int
+
+	// mean is the mean of all observations.
+	mean float64
+
+	// m2 is the sum of the squared differences from the mean, which is updated
+	// using Welford's algorithm.
+	m2 float64
+
+	// sum is the sum of all observations.
+	sum float64
+
+	// min is the minimum of all observations.
+	min float64
+
+	// max is the maximum of all observations.
+	max float64
+}
+
+// Add adds the given observation.
+func (tally *Tally) Add(x float64) {
+	tally.count++
+	tally.sum += x
+
+	if tally.count == 1 || x < tally.min {
+		tally.min = x
+	}
+
+	if tally.count == 1 || x > tally.max {
+		tally.max = x
+	}
+
+	delta := x - tally.mean
+	tally.mean += delta / float64(tally.count)
+	tally.m2 += delta * (x - tally.mean)
+}
+
+// Count returns the number of observations.
+func (tally *Tally) Count() int {
+	return tally.count
+}
+
+// Sum returns the sum of all observations.
+func (tally *Tally) Sum() float64 {
+	return tally.sum
+}
+
+// Mean returns the mean of all observations, or NaN if there are none.
+func (tally *Tally) Mean() float64 {
+	if tally.count == 0 {
+		return math.NaN()
+	}
+
+	return tally.mean
+}
+
+// Variance returns the sample variance of all observations, or NaN if there
+// are less than two.
+func (tally *Tally) Variance() float64 {
+	if tally.count < 2 {
+		return math.NaN()
+	}
+
+	return tally.m2 / float64(tally.count-1)
+}
+
+// StdDev returns the sample standard deviation of all observations, or NaN if
+// there are less than two.
+func (tally *Tally) StdDev() float64 {
+	return math.Sqrt(tally.Variance())
+}
+
+// HalfWidth returns the half-width of the confidence interval for the mean
+// with the given confidence level like 0.95, based on the Student's t
+// distribution. Returns NaN if there are less than two observations. Panics if
+// the level is not between 0 and 1.
+func (tally *Tally) HalfWidth(level float64) float64 {
+	if level <= 0 || level >= 1 {
+		panic(fmt.Sprintf("(*Tally).HalfWidth: level must be between 0 and 1: %f", level))
+	}
+
+	if tally.count < 2 {
+		return math.NaN()
+	}
+
+	t := studentTQuantile(1-(1-level)/2, float64(tally.count-1))
+	return t * tally.StdDev() / math.Sqrt(float64(tally.count))
+}
+
+// Min returns the minimum of all observations, or NaN if there are none.
+func (tally *Tally) Min() float64 {
+	if tally.count == 0 {
+		return math.NaN()
+	}
+
+	return tally.min
+}
+
+// Max returns the maximum of all observations, or NaN if there are none.
+func (tally *Tally) Max() float64 {
+	if tally.count == 0 {
+		return math.NaN()
+	}
+
+	return tally.max
+}
+
+// Reset removes all observations.
+func (tally *Tally) Reset() {
+	*tally = Tally{}
+}
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func assertf(t *testing.T, condition bool, format string, args ...any) {
+	t.Helper()
+	if !condition {
+		t.Errorf(format, args...)
+	}
+}
+
+func TestTally(t *testing.T) {
+	var tally Tally
+
+	assertf(t, math.IsNaN(tally.Mean()), "tally.Mean() == %f", tally.Mean())
+
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		tally.Add(x)
+	}
+
+	assertf(t, tally.Count() == 8, "tally.Count() == %d", tally.Count())
+	assertf(t, tally.Sum() == 40, "tally.Sum() == %f", tally.Sum())
+	assertf(t, tally.Mean() == 5, "tally.Mean() == %f", tally.Mean())
+	assertf(t, math.Abs(tally.Variance()-32.0/7) < 1e-12, "tally.Variance() == %f", tally.Variance())
+	assertf(t, tally.Min() == 2, "tally.Min() == %f", tally.Min())
+	assertf(t, tally.Max() == 9, "tally.Max() == %f", tally.Max())
+
+	tally.Reset()
+
+	assertf(t, tally.Count() == 0, "tally.Count() == %d", tally.Count())
+	assertf(t, math.IsNaN(tally.Max()), "tally.Max() == %f", tally.Max())
+}
+
+func TestTallyVarianceSingle(t *testing.T) {
+	var tally Tally
+	tally.Add(1)
+
+	assertf(t, math.IsNaN(tally.Variance()), "tally.Variance() == %f", tally.Variance())
+}
@@ -0,0 +1,137 @@
+package stats
+
+import (
+	"math"
+)
+
+// Clock provides the current simulation time. *simgo.Simulation and
+// simgo.Process implement Clock.
+type Clock interface {
+	// Now must return the current simulation time.
+	Now() float64
+}
+
+// TimeWeighted accumulates a value which changes over the simulation time,
+// like a queue length or the number of busy servers, and computes its
+// time-weighted mean and variance.
+//
+// To create a new accumulator, use NewTimeWeighted.
+type TimeWeighted struct {
+	// clock provides the current simulation time.
+	clock Clock
+
+	// start is the simulation time of the last reset.
+	start float64
+
+	// last is the simulation time of the last update.
+	last float64
+
+	// value is the current value.
+	value float64
+
+	// area is the integral of the value between start and last.
+	area float64
+
+	// area2 is the integral of the squared value between start and last.
+	area2 float64
+
+	// min is the minimum value since the last reset.
+	min float64
+
+	// max is the maximum value since the last reset.
+	max float64
+}
+
+// NewTimeWeighted creates an accumulator bound to the given clock with the
+// given initial value.
+func NewTimeWeighted(clock Clock, value float64) *TimeWeighted {
+	now := clock.Now()
+	return &TimeWeighted{clock: clock, start: now, last: now, value: value, min: value, max: value}
+}
+
+// Update sets the current value.
+func (tw *TimeWeighted) Update(value float64) {
+	tw.advance()
+	tw.value = value
+
+	if value < tw.min {
+		tw.min = value
+	}
+
+	if value > tw.max {
+		tw.max = value
+	}
+}
+
+// Value returns the current value.
+func (tw *TimeWeighted) Value() float64 {
+	return tw.value
+}
+
+// Duration returns the simulation time passed since the accumulator was
+// created or last reset.
+func (tw *TimeWeighted) Duration() float64 {
+	return tw.clock.Now() - tw.start
+}
+
+// Mean returns the time-weighted mean of the value since the last reset.
+// Returns the current value if no simulation time has passed since then.
+func (tw *TimeWeighted) Mean() float64 {
+	tw.advance()
+
+	duration := tw.Duration()
+	if duration == 0 {
+		return tw.value
+	}
+
+	return tw.area / duration
+}
+
+// Variance returns the time-weighted variance of the value since the last
+// reset. Returns 0 if no simulation time has passed since then.
+func (tw *TimeWeighted) Variance() float64 {
+	tw.advance()
+
+	duration := tw.Duration()
+	if duration == 0 {
+		return 0
+	}
+
+	mean := tw.area / duration
+	return math.Max(tw.area2/duration-mean*mean, 0)
+}
+
+// StdDev returns the time-weighted standard deviation of the value since the
+// last reset.
+func (tw *TimeWeighted) StdDev() float64 {
+	return math.Sqrt(tw.Variance())
+}
+
+// Min returns the minimum value since the last reset.
+func (tw *TimeWeighted) Min() float64 {
+	return tw.min
+}
+
+// Max returns the maximum value since the last reset.
+func (tw *TimeWeighted) Max() float64 {
+	return tw.max
+}
+
+// Reset restarts the accumulation at the current simulation time, keeping the
+// current value.
+func (tw *TimeWeighted) Reset() {
+	tw.start = tw.clock.Now()
+	tw.last = tw.start
+	tw.area = 0
+	tw.area2 = 0
+	tw.min = tw.value
+	tw.max = tw.value
+}
+
+// advance adds the area under the current value since the last update.
+func (tw *TimeWeighted) advance() {
+	now := tw.clock.Now()
+	tw.area += tw.value * (now - tw.last)
+	tw.area2 += tw.value * tw.value * (now - tw.last)
+	tw.last = now
+}
@@ -0,0 +1,38 @@
+package stats
+
+import "testing"
+
+// clock is a manually advanced clock.
+type clock float64
+
+// Now returns the current time.
+func (c *clock) Now() float64 {
+	return float64(*c)
+}
+
+func TestTimeWeighted(t *testing.T) {
+	var now clock
+	tw := NewTimeWeighted(&now, 2)
+
+	assertf(t, tw.Mean() == 2, "tw.Mean() == %f", tw.Mean())
+	assertf(t, tw.Variance() == 0, "tw.Variance() == %f", tw.Variance())
+
+	now = 2
+	tw.Update(4)
+	now = 4
+	tw.Update(0)
+	now = 8
+
+	assertf(t, tw.Mean() == 1.5, "tw.Mean() == %f", tw.Mean())
+	assertf(t, tw.Variance() == 2.75, "tw.Variance() == %f", tw.Variance())
+	assertf(t, tw.Min() == 0, "tw.Min() == %f", tw.Min())
+	assertf(t, tw.Max() == 4, "tw.Max() == %f", tw.Max())
+	assertf(t, tw.Duration() == 8, "tw.Duration() == %f", tw.Duration())
+
+	tw.Reset()
+	now = 10
+
+	assertf(t, tw.Mean() == 0, "tw.Mean() == %f", tw.Mean())
+	assertf(t, tw.Max() == 0, "tw.Max() == %f", tw.Max())
+	assertf(t, tw.Duration() == 2, "tw.Duration() == %f", tw.Duration())
+}
@@ -1,11 +1,15 @@
 package simgo
 
 import (
+	"container/heap"
 	"math"
+
+	"github.com/fschuetz04/simgo/stats"
 )
 
 // Store is a resource for storing objects. The objects are put and retrieved
-// from the store in a first-in first-out order.
+// from the store in a first-in first-out order, unless the store was created
+// with NewPriorityStore or NewPriorityStoreWithCapacity.
 type Store[T any] struct {
 	// sim is the reference to the simulation.
 	sim *Simulation
@@ -21,6 +25,35 @@ type Store[T any] struct {
 
 	// capacity is the maximum number of items in the store.
 	capacity int
+
+	// less returns whether item a is retrieved before item b, or is nil if the
+	// items are retrieved in a first-in first-out order. If it is not nil, the
+	// items are stored as a heap.
+	less func(a, b T) bool
+
+	// count accumulates the number of items in the store.
+	count *stats.TimeWeighted
+
+	// getQueue accumulates statistics about the pending get events.
+	getQueue queueMonitor
+
+	// putQueue accumulates statistics about the pending put events.
+	putQueue queueMonitor
+}
+
+// StoreStats holds statistics about a store since the last reset.
+type StoreStats struct {
+	// MeanItems is the time-weighted average number of items in the store.
+	MeanItems float64
+
+	// MaxItems is the maximum number of items in the store.
+	MaxItems int
+
+	// Gets holds statistics about the queue of pending get events.
+	Gets QueueStats
+
+	// Puts holds statistics about the queue of pending put events.
+	Puts QueueStats
 }
 
 // GetEvent is the event returned from (*Store).Get.
@@ -29,8 +62,15 @@ type GetEvent[T any] struct {
 	*Event
 
 	// Item holds the item retrieved from the store after the underlying event is
-	// triggered.
+	// triggered. It is also the value of the underlying event.
 	Item T
+
+	// filter returns whether the given item can be retrieved, or is nil if any
+	// item can be retrieved.
+	filter func(item T) bool
+
+	// requested is the simulation time at which the item was requested.
+	requested float64
 }
 
 // PutEvent is the returned from (*Store).Put.
@@ -40,6 +80,9 @@ type PutEvent[T any] struct {
 
 	// item holds the item to be returned to the store.
 	item T
+
+	// requested is the simulation time at which the item was offered.
+	requested float64
 }
 
 // NewStore creates a store for the given simulation with an unlimited capacity.
@@ -54,7 +97,35 @@ func NewStoreWithCapacity[T any](sim *Simulation, capacity int) *Store[T] {
 		panic("NewStoreWithCapacity: capacity must be > 0")
 	}
 
-	return &Store[T]{sim: sim, capacity: capacity}
+	return &Store[T]{
+		sim:      sim,
+		capacity: capacity,
+		count:    stats.NewTimeWeighted(sim, 0),
+		getQueue: newQueueMonitor(sim),
+		putQueue: newQueueMonitor(sim),
+	}
+}
+
+// NewPriorityStore creates a priority store for the given simulation with an
+// unlimited capacity. Items are retrieved from the store in the order given by
+// less, which returns whether item a is retrieved before item b. The order of
+// items for which neither is retrieved before the other is unspecified.
+func NewPriorityStore[T any](sim *Simulation, less func(a, b T) bool) *Store[T] {
+	return NewPriorityStoreWithCapacity(sim, math.MaxInt, less)
+}
+
+// NewPriorityStoreWithCapacity creates a priority store for the given
+// simulation with the given capacity.
+//
+// See NewPriorityStore for further documentation.
+func NewPriorityStoreWithCapacity[T any](sim *Simulation, capacity int, less func(a, b T) bool) *Store[T] {
+	if less == nil {
+		panic("NewPriorityStoreWithCapacity: less must not be nil")
+	}
+
+	store := NewStoreWithCapacity[T](sim, capacity)
+	store.less = less
+	return store
 }
 
 // Capacity returns the capacity of the store.
@@ -67,15 +138,53 @@ func (store *Store[T]) Available() int {
 	return len(store.items)
 }
 
+// Stats returns statistics about the store since it was created or since the
+// last call to (*Store).ResetStats.
+func (store *Store[T]) Stats() StoreStats {
+	return StoreStats{
+		MeanItems: store.count.Mean(),
+		MaxItems:  int(store.count.Max()),
+		Gets:      store.getQueue.stats(),
+		Puts:      store.putQueue.stats(),
+	}
+}
+
+// ResetStats resets the statistics about the store, for example after a
+// warm-up period.
+func (store *Store[T]) ResetStats() {
+	store.count.Reset()
+	store.getQueue.reset()
+	store.putQueue.reset()
+}
+
 // Get returns an event that is triggered when an item is retrieved from the
-// store, which may be immediately.
+// store, which may be immediately. To cancel a pending get, use
+// (*GetEvent).Cancel.
 func (store *Store[T]) Get() *GetEvent[T] {
-	ev := &GetEvent[T]{Event: store.sim.Event()}
+	return store.GetFilter(nil)
+}
+
+// GetFilter returns an event that is triggered when the first item for which
+// the given filter returns true is retrieved from the store, which may be
+// immediately. If the filter is nil, any item can be retrieved. For a priority
+// store, the first matching item in priority order is retrieved.
+//
+// Pending get events are served in a first-in first-out order, but a pending
+// get event for which no item matches does not block the following ones. The
+// filters of pending get events are evaluated again whenever an item is put
+// into the store.
+func (store *Store[T]) GetFilter(filter func(item T) bool) *GetEvent[T] {
+	ev := &GetEvent[T]{Event: store.sim.Event(), filter: filter, requested: store.sim.Now()}
 	ev.AddHandler(func(*Event) {
 		// the store has one less item, so check whether any pending puts can be
 		// triggered.
 		store.triggerPuts()
 	})
+	ev.AddAbortHandler(func(*Event) {
+		// the get is cancelled, so remove it
+		store.gets = removeEvent(store.gets, ev)
+		store.observe()
+	})
 
 	store.gets = append(store.gets, ev)
 	store.triggerGets()
@@ -83,15 +192,59 @@ func (store *Store[T]) Get() *GetEvent[T] {
 	return ev
 }
 
+// String returns a description of the get.
+func (ev *GetEvent[T]) String() string {
+	if ev.filter != nil {
+		return "get with filter from store"
+	}
+
+	return "get from store"
+}
+
+// String returns a description of the put.
+func (ev *PutEvent[T]) String() string {
+	return "put into store"
+}
+
+// Cancel cancels the get while it is pending by aborting the underlying event.
+// The get is removed from the queue of the store.
+//
+// Panics if the get was already triggered.
+func (ev *GetEvent[T]) Cancel() {
+	if ev.Triggered() {
+		panic("(*GetEvent).Cancel: get was already triggered")
+	}
+
+	ev.Abort()
+}
+
+// Cancel cancels the put while it is pending by aborting the underlying event.
+// The put is removed from the queue of the store.
+//
+// Panics if the put was already triggered.
+func (ev *PutEvent[T]) Cancel() {
+	if ev.Triggered() {
+		panic("(*PutEvent).Cancel: put was already triggered")
+	}
+
+	ev.Abort()
+}
+
 // Put returns an event that is triggered when the given item is returned to the
-// store, which may be immediately.
+// store, which may be immediately. To cancel a pending put, use
+// (*PutEvent).Cancel.
 func (store *Store[T]) Put(item T) *PutEvent[T] {
-	ev := &PutEvent[T]{Event: store.sim.Event(), item: item}
+	ev := &PutEvent[T]{Event: store.sim.Event(), item: item, requested: store.sim.Now()}
 	ev.AddHandler(func(*Event) {
 		// the store has one more item, so check whether any pending gets can be
 		// triggered
 		store.triggerGets()
 	})
+	ev.AddAbortHandler(func(*Event) {
+		// the put is cancelled, so remove it
+		store.puts = removeEvent(store.puts, ev)
+		store.observe()
+	})
 
 	store.puts = append(store.puts, ev)
 	store.triggerPuts()
@@ -99,22 +252,90 @@ func (store *Store[T]) Put(item T) *PutEvent[T] {
 	return ev
 }
 
-// triggerGets triggers pending get events until the store is empty.
-// left in the store.
+// triggerGets triggers pending get events in order until the store is empty
+// or no item matches the filter of any pending get event.
 func (store *Store[T]) triggerGets() {
-	for len(store.gets) > 0 && len(store.items) > 0 {
-		get := store.gets[0]
-		store.gets = store.gets[1:]
+	for i := 0; i < len(store.gets) && len(store.items) > 0; {
+		get := store.gets[i]
 
-		if !get.Trigger() {
+		if !get.Pending() {
+			// the get event was aborted, so remove it
+			store.gets = append(store.gets[:i], store.gets[i+1:]...)
 			continue
 		}
 
-		item := store.items[0]
-		store.items = store.items[1:]
+		j := store.match(get.filter)
+		if j < 0 {
+			// no item matches, so check the next get event
+			i++
+			continue
+		}
+
+		store.gets = append(store.gets[:i], store.gets[i+1:]...)
+
+		item := store.items[j]
+		if !get.Succeed(item) {
+			continue
+		}
+
+		store.remove(j)
 
 		get.Item = item
+		store.getQueue.serve(get.requested)
+	}
+
+	store.observe()
+}
+
+// match returns the index of the first item for which the given filter returns
+// true, or -1 if no item matches. If the filter is nil, any item matches.
+func (store *Store[T]) match(filter func(item T) bool) int {
+	if filter == nil {
+		// the first item is also the first item of a heap
+		return 0
+	}
+
+	match := -1
+	for i, item := range store.items {
+		if !filter(item) {
+			continue
+		}
+
+		if store.less == nil {
+			return i
+		}
+
+		if match < 0 || store.less(item, store.items[match]) {
+			match = i
+		}
+	}
+
+	return match
+}
+
+// insert inserts the given item into the store.
+func (store *Store[T]) insert(item T) {
+	if store.less != nil {
+		heap.Push((*storeHeap[T])(store), item)
+		return
 	}
+
+	store.items = append(store.items, item)
+}
+
+// remove removes the item at position i from the store.
+func (store *Store[T]) remove(i int) {
+	if store.less != nil {
+		heap.Remove((*storeHeap[T])(store), i)
+		return
+	}
+
+	if i == 0 {
+		store.items = store.items[1:]
+		return
+	}
+
+	store.items = append(store.items[:i], store.items[i+1:]...)
 }
 
 // triggerPuts triggers pending put events until the store is full.
@@ -127,6 +348,48 @@ func (store *Store[T]) triggerPuts() {
 			continue
 		}
 
-		store.items = append(store.items, put.item)
+		store.insert(put.item)
+		store.putQueue.serve(put.requested)
 	}
+
+	store.observe()
+}
+
+// observe updates the statistics with the current state of the store.
+func (store *Store[T]) observe() {
+	store.count.Update(float64(len(store.items)))
+	store.getQueue.length.Update(float64(len(store.gets)))
+	store.putQueue.length.Update(float64(len(store.puts)))
+}
+
+// storeHeap implements heap.Interface for the items of a priority store.
+type storeHeap[T any] Store[T]
+
+// Len returns the number of items.
+func (h *storeHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// Less returns whether the item at position i is retrieved before the item at
+// position j.
+func (h *storeHeap[T]) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+// Swap swaps the items at position i and j.
+func (h *storeHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+// Push appends the given item at the back.
+func (h *storeHeap[T]) Push(item any) {
+	h.items = append(h.items, item.(T))
+}
+
+// Pop removes and returns the item at the back.
+func (h *storeHeap[T]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items = h.items[:n-1]
+	return item
 }
@@ -131,3 +131,127 @@ func TestStoreImmediateGet(t *testing.T) {
 	sim.Run()
 	assertf(t, finished == true, "finished == false")
 }
+
+func TestStoreGetFilter(t *testing.T) {
+	sim := NewSimulation()
+	store := NewStore[int](sim)
+	finished := false
+
+	sim.Process(func(proc Process) {
+		store.Put(1)
+		store.Put(3)
+
+		// an item matches, immediate get request
+		even_ev := store.GetFilter(func(item int) bool { return item%2 == 0 })
+
+		assertf(t, len(store.gets) == 1, "len(store.gets) == %d", len(store.gets))
+		assertf(t, !even_ev.Triggered(), "even_ev.Triggered() == true")
+
+		// the first item matches
+		odd_ev := store.GetFilter(func(item int) bool { return item%2 == 1 })
+
+		assertf(t, len(store.gets) == 1, "len(store.gets) == %d", len(store.gets))
+		assertf(t, odd_ev.Triggered(), "odd_ev.Triggered() == false")
+		assertf(t, odd_ev.Item == 1, "odd_ev.Item == %d", odd_ev.Item)
+
+		// the pending get event with a filter does not block other get events
+		any_ev := store.Get()
+
+		assertf(t, len(store.gets) == 1, "len(store.gets) == %d", len(store.gets))
+		assertf(t, any_ev.Triggered(), "any_ev.Triggered() == false")
+		assertf(t, any_ev.Item == 3, "any_ev.Item == %d", any_ev.Item)
+
+		// a matching item is put, so the pending get event is triggered
+		store.Put(5)
+		store.Put(4)
+		proc.Wait(even_ev)
+
+		assertf(t, len(store.gets) == 0, "len(store.gets) == %d", len(store.gets))
+		assertf(t, even_ev.Item == 4, "even_ev.Item == %d", even_ev.Item)
+		assertf(t, store.Available() == 1, "store.Available() == %d", store.Available())
+
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestPriorityStore(t *testing.T) {
+	sim := NewSimulation()
+	store := NewPriorityStoreWithCapacity(sim, 3, func(a, b int) bool { return a < b })
+	finished := false
+
+	sim.Process(func(proc Process) {
+		store.Put(5)
+		store.Put(2)
+		store.Put(8)
+
+		// store is full, put request queued
+		put_ev := store.Put(1)
+
+		assertf(t, len(store.puts) == 1, "len(store.puts) == %d", len(store.puts))
+		assertf(t, !put_ev.Triggered(), "put_ev.Triggered() == true")
+
+		// item with the highest priority is retrieved first
+		get_ev := store.Get()
+
+		assertf(t, get_ev.Item == 2, "get_ev.Item == %d", get_ev.Item)
+
+		// put request will now be triggered
+		proc.Wait(put_ev)
+
+		assertf(t, len(store.puts) == 0, "len(store.puts) == %d", len(store.puts))
+
+		// first matching item in priority order is retrieved
+		get_ev = store.GetFilter(func(item int) bool { return item > 4 })
+
+		assertf(t, get_ev.Item == 5, "get_ev.Item == %d", get_ev.Item)
+
+		get_ev = store.Get()
+
+		assertf(t, get_ev.Item == 1, "get_ev.Item == %d", get_ev.Item)
+
+		get_ev = store.Get()
+
+		assertf(t, get_ev.Item == 8, "get_ev.Item == %d", get_ev.Item)
+		assertf(t, store.Available() == 0, "store.Available() == %d", store.Available())
+
+		finished = true
+	})
+
+	sim.Run()
+	assertf(t, finished == true, "finished == false")
+}
+
+func TestStoreCancel(t *testing.T) {
+	sim := NewSimulation()
+	store := NewStoreWithCapacity[int](sim, 1)
+
+	get := store.Get()
+	get.Cancel()
+	assertf(t, get.Aborted(), "get.Aborted() == false")
+	assertf(t, len(store.gets) == 0, "len(store.gets) == %d", len(store.gets))
+
+	// the cancelled get does not consume the item put later
+	store.Put(1)
+	sim.Run()
+	assertf(t, store.Available() == 1, "store.Available() == %d", store.Available())
+
+	put := store.Put(2)
+	put.Cancel()
+	assertf(t, len(store.puts) == 0, "len(store.puts) == %d", len(store.puts))
+	sim.Run()
+	assertf(t, store.Available() == 1, "store.Available() == %d", store.Available())
+}
+
+func TestStoreCancelTriggered(t *testing.T) {
+	defer func() {
+		err := recover()
+		assertf(t, err != nil, "err == nil")
+	}()
+
+	sim := NewSimulation()
+	store := NewStore[int](sim)
+	store.Put(1).Cancel()
+}